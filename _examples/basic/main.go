@@ -9,9 +9,8 @@ import (
 
 func main() {
 	kassa := payment.NewKassa().SetShopID("YOUR_SHOP_ID").SetSecretKey("YOUR_API_SECRET_KEY")
-	resp, err := payment.NewPayment().
+	p := payment.NewPayment().
 		SetKassa(kassa).
-		SetIdempotenceKey("RANDOM_STRING"). // Just use UUID v4
 		SetAmount(decimal.NewFromInt(666), "RUB").
 		SetCapture(true).
 		SetConfirmation(
@@ -19,10 +18,15 @@ func main() {
 				Type:      payment.Redirect,
 				ReturnURL: "https://www.merchant-website.com/return_url",
 			}).
-		SetDescription("Default GoYooKassa test").Do()
+		SetDescription("Default GoYooKassa test")
+
+	// Idempotence key is generated by Do, persist it if you want to repeat the request later
+	resp, err := p.Do()
 	if err != nil {
+		fmt.Printf("Idempotence key: %s\n", payment.IdempotenceKeyOf(err))
 		panic(err)
 	}
+	fmt.Printf("Idempotence key: %s\n", resp.IdempotenceKey)
 
 	s, _ := json.MarshalIndent(resp, "", "\t")
 	fmt.Printf("%v\n", string(s))
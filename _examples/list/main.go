@@ -8,6 +8,11 @@ import (
 
 func main() {
 	kassa := payment.NewKassa().SetShopID("YOUR_SHOP_ID").SetSecretKey("YOUR_API_SECRET_KEY")
-	s, _ := json.MarshalIndent(kassa.ListPayments(), "", "\t")
+	list, err := kassa.ListPayments(nil)
+	if err != nil {
+		panic(err)
+	}
+
+	s, _ := json.MarshalIndent(list, "", "\t")
 	fmt.Printf("%v\n", string(s))
 }
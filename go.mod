@@ -2,4 +2,4 @@ module github.com/hugmouse/goyookassa
 
 go 1.17
 
-require github.com/shopspring/decimal v1.2.0 // indirect
+require github.com/shopspring/decimal v1.2.0
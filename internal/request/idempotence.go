@@ -0,0 +1,70 @@
+package request
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"fmt"
+)
+
+// operationIDKey is context key of operation id
+type operationIDKey struct{}
+
+// operationNamespace is namespace of UUID v5 idempotence keys derived from operation ids
+var operationNamespace = [16]byte{
+	0x6b, 0x1f, 0x3c, 0x2e, 0x8a, 0x4d, 0x4f, 0x0b, 0x9e, 0x5a, 0x71, 0xc2, 0x0d, 0x3e, 0x64, 0x15,
+}
+
+// WithOperationID returns a copy of ctx that carries operation id
+func WithOperationID(ctx context.Context, operationID string) context.Context {
+	return context.WithValue(ctx, operationIDKey{}, operationID)
+}
+
+// OperationID returns operation id carried by ctx, or empty string if there is none
+func OperationID(ctx context.Context) string {
+	operationID, _ := ctx.Value(operationIDKey{}).(string)
+	return operationID
+}
+
+// OperationScope returns operation id scoped by the request method and path
+func OperationScope(method, path, operationID string) string {
+	return method + " " + path + " " + operationID
+}
+
+// NewIdempotenceKey returns a new random UUID v4
+func NewIdempotenceKey() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err)
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// IdempotenceKeyFromOperation derives UUID v5 idempotence key from the scoped operation id
+func IdempotenceKeyFromOperation(method, path, operationID string) string {
+	h := sha1.New()
+	h.Write(operationNamespace[:])
+	h.Write([]byte(OperationScope(method, path, operationID)))
+	b := h.Sum(nil)
+	b[6] = b[6]&0x0f | 0x50
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// Operation prepares a POST request of a builder: it returns ctx with operationID,
+// or ctx as is if operationID is empty, and the idempotence key to send the request with.
+// If key is empty, it is derived from the operation id the request is sent with, or a random one is generated
+func Operation(ctx context.Context, method, path, operationID, key string) (context.Context, string) {
+	if operationID != "" {
+		ctx = WithOperationID(ctx, operationID)
+	}
+	if operationID = OperationID(ctx); operationID != "" && key == "" {
+		key = IdempotenceKeyFromOperation(method, path, operationID)
+	}
+	if key == "" {
+		key = NewIdempotenceKey()
+	}
+	return ctx, key
+}
@@ -0,0 +1,56 @@
+// Package request contains helpers shared by the API packages of goyookassa
+package request
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// TimeFormat is format of time in URL query parameters of list requests
+const TimeFormat = "2006-01-02T15:04:05.000Z07:00"
+
+// ListParams is filter by creation time and status together with pagination,
+// shared by list requests of payments, refunds and receipts
+type ListParams struct {
+	// CreatedAtGte filters objects created at the specified time or later
+	CreatedAtGte time.Time
+	// CreatedAtGt filters objects created later than the specified time
+	CreatedAtGt time.Time
+	// CreatedAtLte filters objects created at the specified time or earlier
+	CreatedAtLte time.Time
+	// CreatedAtLt filters objects created earlier than the specified time
+	CreatedAtLt time.Time
+	// Status filters objects by status
+	Status string
+	// Limit is size of the page
+	Limit int
+	// Cursor is next_cursor of the previous page
+	Cursor string
+}
+
+// Query returns params as URL query parameters, zero params are omitted
+func (p ListParams) Query() url.Values {
+	q := url.Values{}
+	SetTime(q, "created_at.gte", p.CreatedAtGte)
+	SetTime(q, "created_at.gt", p.CreatedAtGt)
+	SetTime(q, "created_at.lte", p.CreatedAtLte)
+	SetTime(q, "created_at.lt", p.CreatedAtLt)
+	if p.Status != "" {
+		q.Set("status", p.Status)
+	}
+	if p.Limit != 0 {
+		q.Set("limit", strconv.Itoa(p.Limit))
+	}
+	if p.Cursor != "" {
+		q.Set("cursor", p.Cursor)
+	}
+	return q
+}
+
+// SetTime sets key of q to t in TimeFormat, unless t is zero
+func SetTime(q url.Values, key string, t time.Time) {
+	if !t.IsZero() {
+		q.Set(key, t.UTC().Format(TimeFormat))
+	}
+}
@@ -0,0 +1,259 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/hugmouse/goyookassa/payment"
+	"github.com/hugmouse/goyookassa/refund"
+	"net/http"
+)
+
+// MaxBodySize is maximum size of notification body that Handler accepts
+const MaxBodySize = 1 << 20
+
+// Event is the event that YooKassa notifies about
+//
+// Learn more at: https://yookassa.ru/en/developers/using-api/webhooks#events
+type Event string
+
+const (
+	// PaymentWaitingForCapture is sent when payment has waiting_for_capture status
+	PaymentWaitingForCapture Event = "payment.waiting_for_capture"
+	// PaymentSucceeded is sent when payment has succeeded status
+	PaymentSucceeded Event = "payment.succeeded"
+	// PaymentCanceled is sent when payment has canceled status
+	PaymentCanceled Event = "payment.canceled"
+	// RefundSucceeded is sent when refund has succeeded status
+	RefundSucceeded Event = "refund.succeeded"
+	// PayoutSucceeded is sent when payout has succeeded status
+	PayoutSucceeded Event = "payout.succeeded"
+	// PayoutCanceled is sent when payout has canceled status
+	PayoutCanceled Event = "payout.canceled"
+	// DealClosed is sent when Safe deal has closed status
+	DealClosed Event = "deal.closed"
+)
+
+// Notification is an HTTP notification (webhook) sent by YooKassa
+type Notification struct {
+	// Type is always "notification"
+	Type string `json:"type"`
+	// Event is the event that YooKassa notifies about
+	Event Event `json:"event"`
+	// Object is the object whose status was changed: payment, refund, payout or deal
+	Object json.RawMessage `json:"object"`
+}
+
+// PaymentFunc is called for payment events
+type PaymentFunc func(ctx context.Context, p *payment.PaymentObject) error
+
+// RefundFunc is called for refund events
+type RefundFunc func(ctx context.Context, r *refund.RefundObject) error
+
+// Func is called with raw notification, it is used for events without a typed object, like payouts and deals
+type Func func(ctx context.Context, n *Notification) error
+
+// Handler is an http.Handler that decodes YooKassa notifications and dispatches them
+// to registered callbacks
+//
+// Handler replies:
+//   - 200 OK when the notification was handled, there is no callback for its event,
+//     or it was not verified with the API (see SetClient);
+//   - 4xx when the notification is malformed or rejected by Verifier;
+//   - 500 when the callback has returned an error, so YooKassa will send the notification again later.
+type Handler struct {
+	verifier Verifier
+	client   *payment.Client
+	payments map[Event]PaymentFunc
+	refunds  map[Event]RefundFunc
+	raw      map[Event]Func
+}
+
+var (
+	// ErrMalformed is returned by Handler.Handle when notification can't be decoded
+	ErrMalformed = errors.New("malformed notification")
+	// ErrNotVerified is returned by Handler.Handle when the object fetched from the API
+	// doesn't exist or its status doesn't match the event
+	ErrNotVerified = errors.New("notification was not verified")
+)
+
+// statuses maps events to the status the object must have
+var statuses = map[Event]string{
+	PaymentWaitingForCapture: string(payment.WaitingForCapture),
+	PaymentSucceeded:         string(payment.Succeeded),
+	PaymentCanceled:          string(payment.Canceled),
+	RefundSucceeded:          string(refund.Succeeded),
+}
+
+// NewHandler creates and initializes a new Handler
+func NewHandler() *Handler {
+	return &Handler{
+		payments: make(map[Event]PaymentFunc),
+		refunds:  make(map[Event]RefundFunc),
+		raw:      make(map[Event]Func),
+	}
+}
+
+// SetVerifier sets verifier that checks every request before it is decoded, like IPVerifier
+func (h *Handler) SetVerifier(v Verifier) *Handler {
+	h.verifier = v
+	return h
+}
+
+// SetClient enables re-verification of notifications: before invoking callbacks,
+// the payment or refund is fetched from the API with the client, and callbacks are invoked
+// with the fetched object only if its status matches the event.
+//
+// Notifications are not signed, so use it if IPVerifier is not reliable in your setup,
+// for example behind load balancers. Raw callbacks registered with On are not verified.
+func (h *Handler) SetClient(client *payment.Client) *Handler {
+	h.client = client
+	return h
+}
+
+// OnPayment registers callback for a payment event, like PaymentSucceeded
+func (h *Handler) OnPayment(event Event, f PaymentFunc) *Handler {
+	h.payments[event] = f
+	return h
+}
+
+// OnRefund registers callback for a refund event, like RefundSucceeded
+func (h *Handler) OnRefund(event Event, f RefundFunc) *Handler {
+	h.refunds[event] = f
+	return h
+}
+
+// On registers callback that receives raw notification, like PayoutSucceeded or DealClosed
+func (h *Handler) On(event Event, f Func) *Handler {
+	h.raw[event] = f
+	return h
+}
+
+// Handle dispatches notification to registered callbacks.
+// Errors caused by malformed notification wrap ErrMalformed
+func (h *Handler) Handle(ctx context.Context, n *Notification) error {
+	if n.Type != "notification" {
+		return fmt.Errorf("%w: unexpected type %q", ErrMalformed, n.Type)
+	}
+
+	if f, ok := h.payments[n.Event]; ok {
+		p, err := h.payment(ctx, n)
+		if err != nil {
+			return err
+		}
+		if err := f(ctx, p); err != nil {
+			return err
+		}
+	}
+
+	if f, ok := h.refunds[n.Event]; ok {
+		r, err := h.refund(ctx, n)
+		if err != nil {
+			return err
+		}
+		if err := f(ctx, r); err != nil {
+			return err
+		}
+	}
+
+	if f, ok := h.raw[n.Event]; ok {
+		if err := f(ctx, n); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// payment decodes payment from notification and re-fetches it if client is set
+func (h *Handler) payment(ctx context.Context, n *Notification) (*payment.PaymentObject, error) {
+	p := new(payment.PaymentObject)
+	if err := json.Unmarshal(n.Object, p); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+	if p.ID == "" {
+		return nil, fmt.Errorf("%w: payment id is missing", ErrMalformed)
+	}
+	if h.client == nil {
+		return p, nil
+	}
+
+	fetched, err := h.client.GetPaymentContext(ctx, p.ID)
+	if err != nil {
+		return nil, verificationError(err)
+	}
+	if want, ok := statuses[n.Event]; ok && string(fetched.Status) != want {
+		return nil, fmt.Errorf("%w: payment %s has status %s, event is %s", ErrNotVerified, p.ID, fetched.Status, n.Event)
+	}
+	return fetched, nil
+}
+
+// refund decodes refund from notification and re-fetches it if client is set
+func (h *Handler) refund(ctx context.Context, n *Notification) (*refund.RefundObject, error) {
+	r := new(refund.RefundObject)
+	if err := json.Unmarshal(n.Object, r); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+	if r.ID == "" {
+		return nil, fmt.Errorf("%w: refund id is missing", ErrMalformed)
+	}
+	if h.client == nil {
+		return r, nil
+	}
+
+	fetched, err := refund.NewClient(h.client).GetRefundContext(ctx, r.ID)
+	if err != nil {
+		return nil, verificationError(err)
+	}
+	if want, ok := statuses[n.Event]; ok && string(fetched.Status) != want {
+		return nil, fmt.Errorf("%w: refund %s has status %s, event is %s", ErrNotVerified, r.ID, fetched.Status, n.Event)
+	}
+	return fetched, nil
+}
+
+// verificationError wraps "not found" API error with ErrNotVerified,
+// other errors are returned as is, so the notification will be sent again
+func verificationError(err error) error {
+	if errors.Is(err, payment.ErrNotFound) {
+		return fmt.Errorf("%w: %v", ErrNotVerified, err)
+	}
+	return err
+}
+
+// ServeHTTP decodes notification from request body and dispatches it with Handle
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	if h.verifier != nil {
+		if err := h.verifier.Verify(r); err != nil {
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		}
+	}
+
+	n := new(Notification)
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, MaxBodySize)).Decode(n); err != nil {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Handle(r.Context(), n); err != nil {
+		if errors.Is(err, ErrNotVerified) {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		if errors.Is(err, ErrMalformed) {
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
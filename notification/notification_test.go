@@ -0,0 +1,141 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"github.com/hugmouse/goyookassa/payment"
+	"github.com/hugmouse/goyookassa/refund"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const paymentSucceeded = `{"type":"notification","event":"payment.succeeded","object":` +
+	`{"id":"22d6d597-000f-5000-9000-145f6df21d6f","status":"succeeded","paid":true,` +
+	`"amount":{"value":"2.00","currency":"RUB"},"metadata":{"order_id":"37"},` +
+	`"created_at":"2018-07-10T14:27:54.691Z"}}`
+
+const refundSucceeded = `{"type":"notification","event":"refund.succeeded","object":` +
+	`{"id":"216749f7-0016-50be-b000-078d43a63ae4","status":"succeeded",` +
+	`"amount":{"value":"1.00","currency":"RUB"},"created_at":"2017-10-04T19:27:51.407Z",` +
+	`"payment_id":"216749da-000f-50be-b000-096747fad91e"}}`
+
+func serve(h http.Handler, method, body string) int {
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(method, "/webhook", strings.NewReader(body)))
+	return w.Code
+}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	var gotPayment *payment.PaymentObject
+	var gotRefund *refund.RefundObject
+	h := NewHandler().
+		OnPayment(PaymentSucceeded, func(ctx context.Context, p *payment.PaymentObject) error {
+			gotPayment = p
+			return nil
+		}).
+		OnRefund(RefundSucceeded, func(ctx context.Context, r *refund.RefundObject) error {
+			gotRefund = r
+			return nil
+		}).
+		OnPayment(PaymentCanceled, func(ctx context.Context, p *payment.PaymentObject) error {
+			return errors.New("database is down")
+		})
+
+	if code := serve(h, http.MethodPost, paymentSucceeded); code != http.StatusOK {
+		t.Errorf("payment.succeeded code = %d, want %d", code, http.StatusOK)
+	}
+	if gotPayment == nil || gotPayment.Metadata["order_id"] != "37" {
+		t.Errorf("payment = %+v", gotPayment)
+	}
+
+	if code := serve(h, http.MethodPost, refundSucceeded); code != http.StatusOK {
+		t.Errorf("refund.succeeded code = %d, want %d", code, http.StatusOK)
+	}
+	if gotRefund == nil || gotRefund.Status != refund.Succeeded {
+		t.Errorf("refund = %+v", gotRefund)
+	}
+
+	canceled := strings.Replace(paymentSucceeded, "payment.succeeded", "payment.canceled", 1)
+	if code := serve(h, http.MethodPost, canceled); code != http.StatusInternalServerError {
+		t.Errorf("callback error code = %d, want %d", code, http.StatusInternalServerError)
+	}
+
+	unhandled := strings.Replace(paymentSucceeded, "payment.succeeded", "payment.waiting_for_capture", 1)
+	if code := serve(h, http.MethodPost, unhandled); code != http.StatusOK {
+		t.Errorf("unhandled event code = %d, want %d", code, http.StatusOK)
+	}
+
+	if code := serve(h, http.MethodPost, `{"type":`); code != http.StatusBadRequest {
+		t.Errorf("malformed body code = %d, want %d", code, http.StatusBadRequest)
+	}
+
+	if code := serve(h, http.MethodGet, ""); code != http.StatusMethodNotAllowed {
+		t.Errorf("GET code = %d, want %d", code, http.StatusMethodNotAllowed)
+	}
+}
+
+func TestHandler_SetClient(t *testing.T) {
+	status := "succeeded"
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Path {
+		case "/payments/22d6d597-000f-5000-9000-145f6df21d6f":
+			_, _ = w.Write([]byte(`{"id":"22d6d597-000f-5000-9000-145f6df21d6f","status":"` + status + `",` +
+				`"amount":{"value":"2.00","currency":"RUB"}}`))
+		case "/refunds/216749f7-0016-50be-b000-078d43a63ae4":
+			_, _ = w.Write([]byte(`{"id":"216749f7-0016-50be-b000-078d43a63ae4","status":"succeeded"}`))
+		case "/payments/down":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"type":"error","id":"1","code":"not_found","description":"Not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	calls := 0
+	h := NewHandler().
+		SetClient(payment.NewClient(payment.NewKassa()).SetBaseURL(server.URL)).
+		OnPayment(PaymentSucceeded, func(ctx context.Context, p *payment.PaymentObject) error {
+			calls++
+			return nil
+		}).
+		OnRefund(RefundSucceeded, func(ctx context.Context, r *refund.RefundObject) error {
+			calls++
+			return nil
+		})
+
+	if code := serve(h, http.MethodPost, paymentSucceeded); code != http.StatusOK || calls != 1 {
+		t.Errorf("verified payment code = %d, calls = %d", code, calls)
+	}
+	if code := serve(h, http.MethodPost, refundSucceeded); code != http.StatusOK || calls != 2 {
+		t.Errorf("verified refund code = %d, calls = %d", code, calls)
+	}
+
+	status = "pending"
+	if code := serve(h, http.MethodPost, paymentSucceeded); code != http.StatusOK || calls != 2 {
+		t.Errorf("status mismatch code = %d, calls = %d", code, calls)
+	}
+
+	spoofed := strings.Replace(paymentSucceeded, "22d6d597-000f-5000-9000-145f6df21d6f", "spoofed", 1)
+	if code := serve(h, http.MethodPost, spoofed); code != http.StatusOK || calls != 2 {
+		t.Errorf("spoofed code = %d, calls = %d", code, calls)
+	}
+
+	for _, body := range []string{paymentSucceeded, refundSucceeded} {
+		before := requests
+		noID := strings.Replace(strings.Replace(body, `"id":"22d6d597-000f-5000-9000-145f6df21d6f",`, "", 1),
+			`"id":"216749f7-0016-50be-b000-078d43a63ae4",`, "", 1)
+		if code := serve(h, http.MethodPost, noID); code != http.StatusBadRequest || requests != before || calls != 2 {
+			t.Errorf("missing id code = %d, requests = %d, calls = %d", code, requests-before, calls)
+		}
+	}
+
+	down := strings.Replace(paymentSucceeded, "22d6d597-000f-5000-9000-145f6df21d6f", "down", 1)
+	if code := serve(h, http.MethodPost, down); code != http.StatusInternalServerError || calls != 2 {
+		t.Errorf("api down code = %d, calls = %d", code, calls)
+	}
+}
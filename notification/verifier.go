@@ -0,0 +1,130 @@
+package notification
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// DefaultNetworks is list of IP addresses and ranges YooKassa sends notifications from
+//
+// Learn more at: https://yookassa.ru/en/developers/using-api/webhooks#ip
+var DefaultNetworks = []string{
+	"185.71.76.0/27",
+	"185.71.77.0/27",
+	"77.75.153.0/25",
+	"77.75.156.11",
+	"77.75.156.35",
+	"77.75.154.128/25",
+	"2a02:5180::/32",
+}
+
+// ErrForbiddenIP is returned by IPVerifier when notification was sent from unknown address
+var ErrForbiddenIP = errors.New("notification was sent from forbidden ip address")
+
+// Verifier checks that request is a genuine notification from YooKassa
+type Verifier interface {
+	Verify(r *http.Request) error
+}
+
+// IPVerifier checks that notification was sent from one of YooKassa's networks
+//
+// If your service is behind a reverse proxy or a load balancer, add it to TrustedProxies:
+// X-Forwarded-For header is used only when request came from a trusted proxy.
+type IPVerifier struct {
+	// Networks is list of networks notifications are allowed from
+	Networks []*net.IPNet
+	// TrustedProxies is list of proxies whose X-Forwarded-For header is trusted
+	TrustedProxies []*net.IPNet
+}
+
+// ParseNetworks parses CIDR ranges and single IP addresses
+func ParseNetworks(networks ...string) ([]*net.IPNet, error) {
+	parsed := make([]*net.IPNet, 0, len(networks))
+	for _, network := range networks {
+		if !strings.Contains(network, "/") {
+			ip := net.ParseIP(network)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid ip address %q", network)
+			}
+			bits := 8 * net.IPv6len
+			if ip4 := ip.To4(); ip4 != nil {
+				ip, bits = ip4, 8*net.IPv4len
+			}
+			parsed = append(parsed, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(network)
+		if err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, ipNet)
+	}
+	return parsed, nil
+}
+
+// NewIPVerifier creates and initializes a new IPVerifier with DefaultNetworks
+func NewIPVerifier() *IPVerifier {
+	networks, err := ParseNetworks(DefaultNetworks...)
+	if err != nil {
+		panic(err)
+	}
+	return &IPVerifier{Networks: networks}
+}
+
+// SetNetworks overrides list of networks notifications are allowed from, useful in tests
+func (v *IPVerifier) SetNetworks(networks []*net.IPNet) *IPVerifier {
+	v.Networks = networks
+	return v
+}
+
+// SetTrustedProxies sets list of proxies whose X-Forwarded-For header is trusted
+func (v *IPVerifier) SetTrustedProxies(proxies []*net.IPNet) *IPVerifier {
+	v.TrustedProxies = proxies
+	return v
+}
+
+// Verify checks that request was sent from one of the Networks
+func (v *IPVerifier) Verify(r *http.Request) error {
+	ip := v.clientIP(r)
+	if ip == nil || !contains(v.Networks, ip) {
+		return fmt.Errorf("%w: %s", ErrForbiddenIP, r.RemoteAddr)
+	}
+	return nil
+}
+
+// clientIP returns address of the client. X-Forwarded-For is walked from right to left
+// while addresses belong to trusted proxies
+func (v *IPVerifier) clientIP(r *http.Request) net.IP {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !contains(v.TrustedProxies, ip) {
+		return ip
+	}
+
+	var forwarded []string
+	for _, header := range r.Header.Values("X-Forwarded-For") {
+		forwarded = append(forwarded, strings.Split(header, ",")...)
+	}
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		ip = net.ParseIP(strings.TrimSpace(forwarded[i]))
+		if ip == nil || !contains(v.TrustedProxies, ip) {
+			return ip
+		}
+	}
+	return ip
+}
+
+func contains(networks []*net.IPNet, ip net.IP) bool {
+	for _, network := range networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+package notification
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestIPVerifier_Verify(t *testing.T) {
+	proxies, err := ParseNetworks("10.0.0.0/8")
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := NewIPVerifier().SetTrustedProxies(proxies)
+
+	tests := []struct {
+		name          string
+		remoteAddr    string
+		xForwardedFor string
+		wantErr       bool
+	}{
+		{name: "YooKassa range", remoteAddr: "185.71.76.5:443"},
+		{name: "YooKassa single address", remoteAddr: "77.75.156.11:443"},
+		{name: "YooKassa IPv6", remoteAddr: "[2a02:5180::1]:443"},
+		{name: "Neighbour of single address", remoteAddr: "77.75.156.12:443", wantErr: true},
+		{name: "Unknown address", remoteAddr: "203.0.113.1:443", wantErr: true},
+		{name: "Spoofed X-Forwarded-For from untrusted address", remoteAddr: "203.0.113.1:443",
+			xForwardedFor: "185.71.76.5", wantErr: true},
+		{name: "X-Forwarded-For from trusted proxy", remoteAddr: "10.0.0.1:443",
+			xForwardedFor: "185.71.76.5, 10.0.0.2"},
+		{name: "Spoofed X-Forwarded-For behind trusted proxy", remoteAddr: "10.0.0.1:443",
+			xForwardedFor: "185.71.76.5, 203.0.113.1", wantErr: true},
+		{name: "Trusted proxy without X-Forwarded-For", remoteAddr: "10.0.0.1:443", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/webhook", nil)
+			r.RemoteAddr = tt.remoteAddr
+			if tt.xForwardedFor != "" {
+				r.Header.Set("X-Forwarded-For", tt.xForwardedFor)
+			}
+			err := v.Verify(r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Verify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrForbiddenIP) {
+				t.Errorf("Verify() error = %v, want %v", err, ErrForbiddenIP)
+			}
+		})
+	}
+}
+
+func TestHandler_SetVerifier(t *testing.T) {
+	networks, err := ParseNetworks("192.0.2.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := NewHandler().SetVerifier(NewIPVerifier().SetNetworks(networks))
+
+	// httptest.NewRequest uses 192.0.2.1 as remote address
+	if code := serve(h, http.MethodPost, paymentSucceeded); code != http.StatusOK {
+		t.Errorf("allowed code = %d, want %d", code, http.StatusOK)
+	}
+
+	h.SetVerifier(NewIPVerifier())
+	if code := serve(h, http.MethodPost, paymentSucceeded); code != http.StatusForbidden {
+		t.Errorf("forbidden code = %d, want %d", code, http.StatusForbidden)
+	}
+}
@@ -0,0 +1,39 @@
+package payment
+
+import "net/http"
+
+// Authenticator sets credentials of a request to YooKassa's API
+//
+// BasicAuth authenticates with shop id and secret key (see Kassa.Auth),
+// OAuthToken authenticates partner applications (YooKassa's partner program).
+type Authenticator interface {
+	Authenticate(req *http.Request)
+}
+
+// OAuthToken is OAuth token that was issued to your application by the store owner
+//
+// Learn more at: https://yookassa.ru/en/developers/solutions-for-platforms/partners-api/basics
+type OAuthToken string
+
+// BasicAuth is shop id and secret key sent as HTTP Basic Auth credentials
+type BasicAuth struct {
+	ShopID    string
+	SecretKey string
+}
+
+// Auth returns Kassa's credentials
+//
+// Example: payment.NewClient(nil).SetAuth(kassa.Auth())
+func (c *Kassa) Auth() BasicAuth {
+	return BasicAuth{ShopID: c.ShopID, SecretKey: c.SecretKey}
+}
+
+// Authenticate sets shop id and secret key as HTTP Basic Auth credentials
+func (a BasicAuth) Authenticate(req *http.Request) {
+	req.SetBasicAuth(a.ShopID, a.SecretKey)
+}
+
+// Authenticate sets the token as Bearer credentials
+func (t OAuthToken) Authenticate(req *http.Request) {
+	req.Header.Set("Authorization", "Bearer "+string(t))
+}
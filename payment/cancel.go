@@ -0,0 +1,103 @@
+package payment
+
+import (
+	"context"
+	"github.com/hugmouse/goyookassa/internal/request"
+	"net/http"
+	"net/url"
+)
+
+// Cancel is used to cancel the payment that has waiting_for_capture status.
+// Canceling the payment releases the funds held on the user's account
+//
+// Learn more: https://yookassa.ru/en/developers/api#cancel_payment
+type Cancel struct {
+	*Kassa `json:"-"`
+
+	// IdempotenceKey works the same way as in Payment
+	IdempotenceKey string `json:"-"`
+
+	// OperationID identifies the operation, see IdempotencyStore
+	OperationID string `json:"-"`
+
+	// Client is used to send the request. If nil, a new Client is created for Kassa
+	Client *Client `json:"-"`
+
+	// PaymentID is id of the payment that will be canceled
+	PaymentID string `json:"-"`
+}
+
+// CancellationDetails is commentary to the canceled status: who and why canceled the payment
+//
+// Learn more at: https://yookassa.ru/en/developers/payment-acceptance/after-the-payment/declined-payments
+type CancellationDetails struct {
+	// Party is the participant of the payment process that made the decision to cancel the payment:
+	// yoo_money, payment_network or merchant
+	Party string `json:"party"`
+	// Reason is reason behind the cancellation, like expired_on_capture or insufficient_funds
+	Reason string `json:"reason"`
+}
+
+// NewCancel creates and initializes a new Cancel for a payment with a given id
+func NewCancel(paymentID string) *Cancel {
+	return &Cancel{PaymentID: paymentID}
+}
+
+// SetKassa sets cancel's YooKassa info (your shop id and shop secret key)
+func (c *Cancel) SetKassa(kassa *Kassa) *Cancel {
+	c.Kassa = kassa
+	return c
+}
+
+// SetClient sets client that is used to send the request
+func (c *Cancel) SetClient(client *Client) *Cancel {
+	c.Client = client
+	return c
+}
+
+// SetIdempotenceKey sets cancel's idempotence key
+func (c *Cancel) SetIdempotenceKey(key string) *Cancel {
+	c.IdempotenceKey = key
+	return c
+}
+
+// SetOperationID sets id of the operation, see IdempotencyStore
+func (c *Cancel) SetOperationID(id string) *Cancel {
+	c.OperationID = id
+	return c
+}
+
+// Do sends an HTTP request to YooKassa cancel endpoint
+func (c *Cancel) Do() (*PaymentObject, error) {
+	return c.DoContext(context.Background())
+}
+
+// Validate checks that payment id is set, the error is ValidationErrors
+func (c *Cancel) Validate() error {
+	var errs ValidationErrors
+	if c.PaymentID == "" {
+		errs.Add("payment_id", "payment id is required")
+	}
+	return errs.Err()
+}
+
+// DoContext sends an HTTP request to YooKassa cancel endpoint using the provided context.
+// The cancel is validated first, unless Client.SkipValidation is set
+func (c *Cancel) DoContext(ctx context.Context) (*PaymentObject, error) {
+	path := "payments/" + url.PathEscape(c.PaymentID) + "/cancel"
+	ctx, key := request.Operation(ctx, http.MethodPost, path, c.OperationID, c.IdempotenceKey)
+	client := clientFor(c.Client, c.Kassa)
+	if !client.SkipValidation {
+		if err := c.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	payment := new(PaymentObject)
+	key, err := client.SendWithKey(ctx, http.MethodPost, path, key, struct{}{}, payment)
+	if err != nil {
+		return nil, err
+	}
+	payment.IdempotenceKey = key
+	return payment, nil
+}
@@ -0,0 +1,34 @@
+package payment
+
+import (
+	"github.com/hugmouse/goyookassa/consts"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCancel_Do(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/payments/some-id/cancel" {
+			t.Errorf("request = %s %s, want POST /payments/some-id/cancel", r.Method, r.URL.Path)
+		}
+		if got := r.Header.Get(consts.IdempotentHeader); got != "key" {
+			t.Errorf("Idempotence-Key = %q, want %q", got, "key")
+		}
+		_, _ = w.Write([]byte(`{"id":"some-id","status":"canceled","paid":true,` +
+			`"cancellation_details":{"party":"merchant","reason":"canceled_by_merchant"}}`))
+	}))
+	defer server.Close()
+
+	got, err := NewCancel("some-id").
+		SetClient(NewClient(NewKassa()).SetBaseURL(server.URL)).
+		SetIdempotenceKey("key").
+		Do()
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	want := &CancellationDetails{Party: "merchant", Reason: "canceled_by_merchant"}
+	if got.Status != "canceled" || got.CancellationDetails == nil || *got.CancellationDetails != *want {
+		t.Errorf("Do() = %+v", got)
+	}
+}
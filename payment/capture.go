@@ -0,0 +1,187 @@
+package payment
+
+import (
+	"context"
+	"github.com/hugmouse/goyookassa/internal/request"
+	"github.com/shopspring/decimal"
+	"net/http"
+	"net/url"
+)
+
+// Capture is used to capture the payment that has waiting_for_capture status.
+// The payment may be captured in full or partially
+//
+// Learn more: https://yookassa.ru/en/developers/api#capture_payment
+type Capture struct {
+	*Kassa `json:"-"`
+
+	// IdempotenceKey works the same way as in Payment
+	IdempotenceKey string `json:"-"`
+
+	// OperationID identifies the operation, see IdempotencyStore
+	OperationID string `json:"-"`
+
+	// Client is used to send the request. If nil, a new Client is created for Kassa
+	Client *Client `json:"-"`
+
+	// PaymentID is id of the payment that will be captured
+	PaymentID string `json:"-"`
+
+	// Amount is total amount to capture. If nil, the payment is captured in full
+	Amount *Amount `json:"amount,omitempty"`
+
+	// Receipt is data for creating a receipt. Required if the amount or items have changed
+	Receipt *Receipt `json:"receipt,omitempty"`
+
+	// Airline is an object containing data for selling airline tickets
+	Airline *Airline `json:"airline,omitempty"`
+
+	// Transfers is data for distribution of funds between stores, used in Split payments
+	Transfers []Transfer `json:"transfers,omitempty"`
+}
+
+// Airline is an object containing data for selling airline tickets. Used only for bank card payments
+type Airline struct {
+	// TicketNumber is unique ticket number
+	TicketNumber string `json:"ticket_number,omitempty"`
+	// BookingReference is booking reference number, required if TicketNumber is not specified
+	BookingReference string `json:"booking_reference,omitempty"`
+	// Passengers is list of passengers
+	Passengers []Passenger `json:"passengers,omitempty"`
+	// Legs is list of flight legs
+	Legs []Leg `json:"legs,omitempty"`
+}
+
+// Passenger is a passenger of the Airline
+type Passenger struct {
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+}
+
+// Leg is a flight leg of the Airline
+type Leg struct {
+	// DepartureAirport is IATA code of departure airport
+	DepartureAirport string `json:"departure_airport"`
+	// DestinationAirport is IATA code of destination airport
+	DestinationAirport string `json:"destination_airport"`
+	// DepartureDate in YYYY-MM-DD format
+	DepartureDate string `json:"departure_date"`
+	// CarrierCode is IATA airline code
+	CarrierCode string `json:"carrier_code,omitempty"`
+}
+
+// Transfer is data for distribution of funds between stores
+//
+// Learn more at: https://yookassa.ru/en/developers/solutions-for-platforms/split-payments/basics
+type Transfer struct {
+	// AccountID is id of the store in favor of which you're accepting the payment
+	AccountID string `json:"account_id"`
+	// Amount to be transferred to the store
+	Amount Amount `json:"amount"`
+	// PlatformFeeAmount is commission for sold products and services, which is withheld from the store
+	PlatformFeeAmount *Amount `json:"platform_fee_amount,omitempty"`
+	// Description of the transaction (up to 128 characters)
+	Description string `json:"description,omitempty"`
+	// Status is distribution status, it is set only in responses
+	Status string `json:"status,omitempty"`
+}
+
+// NewCapture creates and initializes a new Capture for a payment with a given id
+func NewCapture(paymentID string) *Capture {
+	return &Capture{PaymentID: paymentID}
+}
+
+// SetKassa sets capture's YooKassa info (your shop id and shop secret key)
+func (c *Capture) SetKassa(kassa *Kassa) *Capture {
+	c.Kassa = kassa
+	return c
+}
+
+// SetClient sets client that is used to send the request
+func (c *Capture) SetClient(client *Client) *Capture {
+	c.Client = client
+	return c
+}
+
+// SetIdempotenceKey sets capture's idempotence key
+func (c *Capture) SetIdempotenceKey(key string) *Capture {
+	c.IdempotenceKey = key
+	return c
+}
+
+// SetOperationID sets id of the operation, see IdempotencyStore
+func (c *Capture) SetOperationID(id string) *Capture {
+	c.OperationID = id
+	return c
+}
+
+// SetAmount sets amount to capture. Use it if you want to capture only a part of the payment
+//
+// Example: payment.NewCapture(id).SetAmount(decimal.NewFromInt(500), "RUB")
+func (c *Capture) SetAmount(value decimal.Decimal, moneyType string) *Capture {
+	c.Amount = &Amount{
+		Value:    value,
+		Currency: moneyType,
+	}
+	return c
+}
+
+// SetReceipt sets updated receipt
+func (c *Capture) SetReceipt(receipt Receipt) *Capture {
+	c.Receipt = &receipt
+	return c
+}
+
+// SetAirline sets airline tickets data
+func (c *Capture) SetAirline(airline Airline) *Capture {
+	c.Airline = &airline
+	return c
+}
+
+// SetTransfers sets distribution of funds between stores
+func (c *Capture) SetTransfers(transfers []Transfer) *Capture {
+	c.Transfers = transfers
+	return c
+}
+
+// Do sends an HTTP request to YooKassa capture endpoint
+func (c *Capture) Do() (*PaymentObject, error) {
+	return c.DoContext(context.Background())
+}
+
+// Validate checks the capture before it is sent: payment id, amount and receipt.
+// The error is ValidationErrors with every invalid parameter
+func (c *Capture) Validate() error {
+	var errs ValidationErrors
+	if c.PaymentID == "" {
+		errs.Add("payment_id", "payment id is required")
+	}
+	if c.Amount != nil {
+		errs.Merge("amount", c.Amount.Validate())
+	}
+	if c.Receipt != nil {
+		errs.Merge("receipt", c.Receipt.Validate(c.Amount))
+	}
+	return errs.Err()
+}
+
+// DoContext sends an HTTP request to YooKassa capture endpoint using the provided context.
+// The capture is validated first, unless Client.SkipValidation is set
+func (c *Capture) DoContext(ctx context.Context) (*PaymentObject, error) {
+	path := "payments/" + url.PathEscape(c.PaymentID) + "/capture"
+	ctx, key := request.Operation(ctx, http.MethodPost, path, c.OperationID, c.IdempotenceKey)
+	client := clientFor(c.Client, c.Kassa)
+	if !client.SkipValidation {
+		if err := c.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	payment := new(PaymentObject)
+	key, err := client.SendWithKey(ctx, http.MethodPost, path, key, c, payment)
+	if err != nil {
+		return nil, err
+	}
+	payment.IdempotenceKey = key
+	return payment, nil
+}
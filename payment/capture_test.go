@@ -0,0 +1,44 @@
+package payment
+
+import (
+	"encoding/json"
+	"github.com/hugmouse/goyookassa/consts"
+	"github.com/shopspring/decimal"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCapture_Do(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/payments/some-id/capture" {
+			t.Errorf("request = %s %s, want POST /payments/some-id/capture", r.Method, r.URL.Path)
+		}
+		if got := r.Header.Get(consts.IdempotentHeader); got != "key" {
+			t.Errorf("Idempotence-Key = %q, want %q", got, "key")
+		}
+		var body map[string]json.RawMessage
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+			return
+		}
+		if got := string(body["amount"]); got != `{"value":"300.00","currency":"RUB"}` {
+			t.Errorf("amount = %s", got)
+		}
+		_, _ = w.Write([]byte(`{"id":"some-id","status":"succeeded","paid":true,` +
+			`"amount":{"value":"300.00","currency":"RUB"}}`))
+	}))
+	defer server.Close()
+
+	got, err := NewCapture("some-id").
+		SetClient(NewClient(NewKassa()).SetBaseURL(server.URL)).
+		SetIdempotenceKey("key").
+		SetAmount(decimal.NewFromInt(300), "RUB").
+		Do()
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	if got.Status != "succeeded" || !got.Amount.Value.Equal(decimal.NewFromInt(300)) {
+		t.Errorf("Do() = %+v", got)
+	}
+}
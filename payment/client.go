@@ -0,0 +1,333 @@
+package payment
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hugmouse/goyookassa/consts"
+	"github.com/hugmouse/goyookassa/internal/request"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultUserAgent is sent in User-Agent header unless Client.UserAgent is set
+const DefaultUserAgent = "goyookassa"
+
+// Client is used to send requests to YooKassa's API on behalf of Kassa
+//
+// Every API call of this library is routed through a Client,
+// so you can set timeouts, proxies, mTLS or point the library at a local stand-in.
+type Client struct {
+	// Kassa provides credentials for every request, unless Auth is set
+	Kassa *Kassa
+
+	// Auth provides credentials for every request, like OAuthToken. If nil, Kassa is used
+	Auth Authenticator
+
+	// HTTPClient is used to send requests. If nil, http.DefaultClient is used
+	HTTPClient *http.Client
+
+	// BaseURL is API endpoint. If empty, consts.Endpoint is used
+	BaseURL string
+
+	// UserAgent is sent in User-Agent header. If empty, DefaultUserAgent is used
+	UserAgent string
+
+	// Header is a set of default headers sent with every request
+	Header http.Header
+
+	// RetryPolicy describes how failed requests are repeated. If nil, requests are not repeated
+	RetryPolicy *RetryPolicy
+
+	// IdempotencyStore persists idempotence keys and responses of operations, see WithOperationID
+	IdempotencyStore IdempotencyStore
+
+	// SkipValidation disables validation of requests before they are sent, see Payment.Validate
+	SkipValidation bool
+}
+
+// NewClient creates and initializes a new Client for a given Kassa
+func NewClient(kassa *Kassa) *Client {
+	return &Client{
+		Kassa:      kassa,
+		HTTPClient: http.DefaultClient,
+		BaseURL:    consts.Endpoint,
+		UserAgent:  DefaultUserAgent,
+		Header:     make(http.Header),
+	}
+}
+
+// SetKassa sets client's YooKassa info (your shop id and shop secret key)
+func (c *Client) SetKassa(kassa *Kassa) *Client {
+	c.Kassa = kassa
+	return c
+}
+
+// SetAuth sets credentials that are used instead of Kassa, like OAuthToken
+//
+// Example: payment.NewClient(nil).SetAuth(payment.OAuthToken(token))
+func (c *Client) SetAuth(auth Authenticator) *Client {
+	c.Auth = auth
+	return c
+}
+
+// SetHTTPClient sets HTTP client that is used to send requests
+//
+// Example: payment.NewClient(kassa).SetHTTPClient(&http.Client{Timeout: 10 * time.Second})
+func (c *Client) SetHTTPClient(client *http.Client) *Client {
+	c.HTTPClient = client
+	return c
+}
+
+// SetBaseURL sets API endpoint, for example a local stand-in used in tests
+func (c *Client) SetBaseURL(baseURL string) *Client {
+	c.BaseURL = baseURL
+	return c
+}
+
+// SetUserAgent sets User-Agent header value
+func (c *Client) SetUserAgent(ua string) *Client {
+	c.UserAgent = ua
+	return c
+}
+
+// SetHeader sets default header that is sent with every request
+func (c *Client) SetHeader(key, value string) *Client {
+	if c.Header == nil {
+		c.Header = make(http.Header)
+	}
+	c.Header.Set(key, value)
+	return c
+}
+
+// SetRetryPolicy sets policy of repeating failed requests
+//
+// Example: payment.NewClient(kassa).SetRetryPolicy(payment.DefaultRetryPolicy())
+func (c *Client) SetRetryPolicy(policy *RetryPolicy) *Client {
+	c.RetryPolicy = policy
+	return c
+}
+
+// SetIdempotencyStore sets store that persists idempotence keys and responses of operations
+func (c *Client) SetIdempotencyStore(store IdempotencyStore) *Client {
+	c.IdempotencyStore = store
+	return c
+}
+
+// SetSkipValidation disables or enables validation of requests before they are sent
+func (c *Client) SetSkipValidation(skip bool) *Client {
+	c.SkipValidation = skip
+	return c
+}
+
+// Send sends an HTTP request to YooKassa's endpoint and decodes response into out
+//
+// The in value is encoded as JSON request body if it is not nil.
+// Idempotence key is generated for POST requests if it is empty, and it is attached to returned errors,
+// see IdempotenceKeyOf.
+// The ctx is attached to the outgoing request, so cancellation and deadlines are propagated.
+//
+// If ctx carries operation id (see WithOperationID), idempotence key of POST request is derived from it,
+// the method and the path. If IdempotencyStore is also set, the key is persisted before POST request is sent,
+// and the stored response is returned when the operation is replayed.
+//
+// If RetryPolicy is set, idempotent requests (GET, DELETE and requests with idempotence key)
+// are repeated with the same idempotence key, see RetryPolicy for details.
+func (c *Client) Send(ctx context.Context, method, path, idempotenceKey string, in, out interface{}) error {
+	_, err := c.SendWithKey(ctx, method, path, idempotenceKey, in, out)
+	return err
+}
+
+// SendWithKey is like Send, but it also returns the idempotence key the request was sent with,
+// which may be generated or taken from IdempotencyStore
+func (c *Client) SendWithKey(ctx context.Context, method, path, idempotenceKey string,
+	in, out interface{}) (string, error) {
+	operationID := OperationIDFrom(ctx)
+	if method != http.MethodPost {
+		operationID = ""
+	}
+	if idempotenceKey == "" && operationID != "" {
+		idempotenceKey = IdempotenceKeyFromOperation(method, path, operationID)
+	}
+	persist := operationID != "" && c.IdempotencyStore != nil
+
+	var record *IdempotencyRecord
+	if persist {
+		operationID = request.OperationScope(method, path, operationID)
+		stored, err := c.IdempotencyStore.Get(ctx, operationID)
+		if err != nil {
+			return "", err
+		}
+		if stored != nil && stored.Response != nil {
+			return stored.IdempotenceKey, decode(stored.Response, out)
+		}
+		if stored != nil {
+			idempotenceKey = stored.IdempotenceKey
+		}
+		record = stored
+	}
+
+	if method == http.MethodPost {
+		ensureIdempotenceKey(&idempotenceKey)
+	}
+	if len(idempotenceKey) > IdempotenceKeyMaxLength {
+		return idempotenceKey, fmt.Errorf("%w: %d characters, maximum is %d", ErrIdempotenceKeyTooLong,
+			len(idempotenceKey), IdempotenceKeyMaxLength)
+	}
+
+	if persist && record == nil {
+		record = &IdempotencyRecord{OperationID: operationID, IdempotenceKey: idempotenceKey}
+		if err := c.IdempotencyStore.Put(ctx, record); err != nil {
+			return "", err
+		}
+	}
+
+	var payload []byte
+	if in != nil {
+		payloadBytes, err := json.Marshal(in)
+		if err != nil {
+			return "", err
+		}
+		payload = payloadBytes
+	}
+
+	idempotent := method == http.MethodGet || method == http.MethodDelete || idempotenceKey != ""
+	for attempt := 1; ; attempt++ {
+		stuff, err := c.send(ctx, method, path, idempotenceKey, payload)
+		if err == nil {
+			if record != nil {
+				record.Response = stuff
+				if err := c.IdempotencyStore.Put(ctx, record); err != nil {
+					return idempotenceKey, withIdempotenceKey(err, idempotenceKey)
+				}
+			}
+			return idempotenceKey, decode(stuff, out)
+		}
+		if !idempotent || !c.RetryPolicy.retryable(ctx, attempt, err) {
+			return idempotenceKey, withIdempotenceKey(err, idempotenceKey)
+		}
+
+		timer := time.NewTimer(c.RetryPolicy.backoff(attempt, err))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return idempotenceKey, withIdempotenceKey(ctx.Err(), idempotenceKey)
+		case <-timer.C:
+		}
+	}
+}
+
+// send makes a single attempt of Send and returns response body
+func (c *Client) send(ctx context.Context, method, path, idempotenceKey string, payload []byte) ([]byte, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint(path), body)
+	if err != nil {
+		return nil, err
+	}
+	c.prepare(req)
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if idempotenceKey != "" {
+		req.Header.Set(consts.IdempotentHeader, idempotenceKey)
+	}
+
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	stuff, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	yooKassaError := &YooKassaErrorResponse{}
+	isJSON := json.Unmarshal(stuff, yooKassaError) == nil
+	if isJSON && yooKassaError.Type == "error" || resp.StatusCode == http.StatusAccepted {
+		yooKassaError.StatusCode = resp.StatusCode
+		return nil, yooKassaError
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, &HTTPError{StatusCode: resp.StatusCode, Status: resp.Status, Body: stuff}
+	}
+
+	return stuff, nil
+}
+
+// decode decodes response body into out, if out is not nil
+func decode(body []byte, out interface{}) error {
+	if out == nil || len(body) == 0 {
+		return nil
+	}
+	return json.Unmarshal(body, out)
+}
+
+// clientFor returns client if it is set, otherwise a new Client is created for kassa
+func clientFor(client *Client, kassa *Kassa) *Client {
+	if client != nil {
+		return client
+	}
+	return NewClient(kassa)
+}
+
+// prepare sets credentials and default headers of the request
+func (c *Client) prepare(req *http.Request) {
+	for key, values := range c.Header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	ua := c.UserAgent
+	if ua == "" {
+		ua = DefaultUserAgent
+	}
+	req.Header.Set("User-Agent", ua)
+	switch {
+	case c.Auth != nil:
+		c.Auth.Authenticate(req)
+	case c.Kassa != nil:
+		c.Kassa.Auth().Authenticate(req)
+	}
+}
+
+func (c *Client) endpoint(path string) string {
+	base := c.BaseURL
+	if base == "" {
+		base = consts.Endpoint
+	}
+	return strings.TrimSuffix(base, "/") + "/" + strings.TrimPrefix(path, "/")
+}
+
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient == nil {
+		return http.DefaultClient
+	}
+	return c.HTTPClient
+}
+
+// GetPayment returns payment by its id
+//
+// API errors are returned as *YooKassaErrorResponse, other unsuccessful responses as *HTTPError.
+func (c *Client) GetPayment(id string) (*PaymentObject, error) {
+	return c.GetPaymentContext(context.Background(), id)
+}
+
+// GetPaymentContext returns payment by its id using the provided context
+func (c *Client) GetPaymentContext(ctx context.Context, id string) (*PaymentObject, error) {
+	payment := new(PaymentObject)
+	if err := c.Send(ctx, http.MethodGet, "payments/"+url.PathEscape(id), "", nil, payment); err != nil {
+		return nil, err
+	}
+	return payment, nil
+}
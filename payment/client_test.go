@@ -0,0 +1,165 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"github.com/shopspring/decimal"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_Send(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v3/payments/some-id" {
+			t.Errorf("path = %q, want %q", r.URL.Path, "/v3/payments/some-id")
+		}
+		if got := r.Header.Get("User-Agent"); got != "test-agent" {
+			t.Errorf("User-Agent = %q, want %q", got, "test-agent")
+		}
+		if got := r.Header.Get("X-Custom"); got != "value" {
+			t.Errorf("X-Custom = %q, want %q", got, "value")
+		}
+		if id, key, ok := r.BasicAuth(); !ok || id != "1" || key != "key" {
+			t.Errorf("BasicAuth() = %q, %q, %v", id, key, ok)
+		}
+		_, _ = w.Write([]byte(`{"id":"some-id","status":"succeeded","paid":true}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(NewKassa().SetShopID("1").SetSecretKey("key")).
+		SetHTTPClient(server.Client()).
+		SetBaseURL(server.URL+"/v3/").
+		SetUserAgent("test-agent").
+		SetHeader("X-Custom", "value")
+
+	got, err := client.GetPayment("some-id")
+	if err != nil {
+		t.Fatalf("GetPayment() error = %v", err)
+	}
+	if got.ID != "some-id" || got.Status != "succeeded" || !got.Paid {
+		t.Errorf("GetPayment() = %+v", got)
+	}
+}
+
+func TestClient_SendContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	client := NewClient(NewKassa()).SetBaseURL(server.URL)
+	_, err := NewPayment().SetClient(client).SetAmount(decimal.NewFromInt(1), "RUB").DoContext(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("DoContext() error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestClient_GetPaymentErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/payments/missing":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"type":"error","id":"1","code":"not_found","description":"Payment not found"}`))
+		default:
+			w.WriteHeader(http.StatusBadGateway)
+			_, _ = w.Write([]byte(`<html>Bad Gateway</html>`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(NewKassa()).SetBaseURL(server.URL)
+
+	_, err := client.GetPayment("missing")
+	var apiErr *YooKassaErrorResponse
+	if !errors.As(err, &apiErr) || apiErr.Code != "not_found" {
+		t.Errorf("GetPayment() error = %v, want *YooKassaErrorResponse with code not_found", err)
+	}
+
+	_, err = client.GetPayment("down")
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusBadGateway {
+		t.Errorf("GetPayment() error = %v, want *HTTPError with status 502", err)
+	}
+}
+
+func TestClient_GetPaymentObject(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"22e12f66-000f-5000-8000-18db351245c7","status":"succeeded","paid":true,` +
+			`"amount":{"value":"2.00","currency":"RUB"},"income_amount":{"value":"1.93","currency":"RUB"},` +
+			`"authorization_details":{"rrn":"10000000000","auth_code":"000000","three_d_secure":{"applied":true}},` +
+			`"captured_at":"2018-07-18T17:20:50.825Z","created_at":"2018-07-18T17:18:39.345Z",` +
+			`"description":"Order No. 72","merchant_customer_id":"user@example.com",` +
+			`"payment_method":{"type":"bank_card","id":"22e12f66-000f-5000-8000-18db351245c7","saved":false,` +
+			`"card":{"first6":"555555","last4":"4444","expiry_month":"07","expiry_year":"2022","card_type":"MasterCard"},` +
+			`"title":"Bank card *4444"},"refundable":true,"refunded_amount":{"value":"0.00","currency":"RUB"},` +
+			`"recipient":{"account_id":"100500","gateway_id":"100700"},"receipt_registration":"succeeded",` +
+			`"transfers":[{"account_id":"123","amount":{"value":"1.00","currency":"RUB"},"status":"succeeded"}],` +
+			`"deal":{"id":"dl-1","settlements":[{"type":"payout","amount":{"value":"1.00","currency":"RUB"}}]},` +
+			`"test":false}`))
+	}))
+	defer server.Close()
+
+	got, err := NewClient(NewKassa()).SetBaseURL(server.URL).GetPayment("22e12f66-000f-5000-8000-18db351245c7")
+	if err != nil {
+		t.Fatalf("GetPayment() error = %v", err)
+	}
+	if got.IncomeAmount == nil || got.IncomeAmount.Value.String() != "1.93" {
+		t.Errorf("IncomeAmount = %+v", got.IncomeAmount)
+	}
+	if got.CapturedAt == nil || got.ExpiresAt != nil {
+		t.Errorf("CapturedAt = %v, ExpiresAt = %v", got.CapturedAt, got.ExpiresAt)
+	}
+	if got.AuthorizationDetails == nil || !got.AuthorizationDetails.ThreeDSecure.Applied {
+		t.Errorf("AuthorizationDetails = %+v", got.AuthorizationDetails)
+	}
+	if got.PaymentMethod == nil || got.PaymentMethod.Card.Last4 != "4444" {
+		t.Errorf("PaymentMethod = %+v", got.PaymentMethod)
+	}
+	if len(got.Transfers) != 1 || got.Transfers[0].Status != "succeeded" {
+		t.Errorf("Transfers = %+v", got.Transfers)
+	}
+	if got.Deal == nil || len(got.Deal.Settlements) != 1 || got.MerchantCustomerID != "user@example.com" {
+		t.Errorf("Deal = %+v, MerchantCustomerID = %q", got.Deal, got.MerchantCustomerID)
+	}
+}
+
+func TestClient_SetAuth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer token" {
+			t.Errorf("Authorization = %q, want %q", got, "Bearer token")
+		}
+		_, _ = w.Write([]byte(`{"id":"some-id"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(NewKassa().SetShopID("1").SetSecretKey("key")).
+		SetAuth(OAuthToken("token")).
+		SetBaseURL(server.URL)
+	if _, err := client.GetPayment("some-id"); err != nil {
+		t.Errorf("GetPayment() error = %v", err)
+	}
+}
+
+func TestBasicAuth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if id, key, ok := r.BasicAuth(); !ok || id != "1" || key != "key" {
+			t.Errorf("BasicAuth() = %q, %q, %v", id, key, ok)
+		}
+		_, _ = w.Write([]byte(`{"id":"some-id"}`))
+	}))
+	defer server.Close()
+
+	kassa := NewKassa().SetShopID("1").SetSecretKey("key")
+	if _, err := NewClient(nil).SetAuth(kassa.Auth()).SetBaseURL(server.URL).GetPayment("some-id"); err != nil {
+		t.Errorf("GetPayment() error = %v", err)
+	}
+
+	var builder interface{} = NewPayment()
+	if _, ok := builder.(Authenticator); ok {
+		t.Errorf("Payment implements Authenticator")
+	}
+}
@@ -0,0 +1,69 @@
+package payment
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestConfirmation_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		conf Confirmation
+		want string
+	}{
+		{name: "Embedded", conf: Confirmation{Type: Embedded, Locale: "en_US", ReturnURL: "https://example.com"},
+			want: `{"type":"embedded","locale":"en_US"}`},
+		{name: "External", conf: Confirmation{Type: External, Enforce: true},
+			want: `{"type":"external"}`},
+		{name: "MobileApplication", conf: Confirmation{Type: MobileApplication, ReturnURL: "app://return"},
+			want: `{"type":"mobile_application","return_url":"app://return"}`},
+		{name: "QR", conf: Confirmation{Type: QR, ConfirmationData: "https://qr.nspk.ru/1"},
+			want: `{"type":"qr","confirmation_data":"https://qr.nspk.ru/1"}`},
+		{name: "Redirect", conf: Confirmation{Type: Redirect, Enforce: true, ReturnURL: "https://example.com"},
+			want: `{"type":"redirect","enforce":true,"return_url":"https://example.com"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.conf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("MarshalJSON() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfirmation_UnmarshalJSON(t *testing.T) {
+	var got PaymentObject
+	err := json.Unmarshal([]byte(`{"confirmation":{"type":"embedded","confirmation_token":"ct-1"}}`), &got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Confirmation.Type != Embedded || got.Confirmation.ConfirmationToken != "ct-1" {
+		t.Errorf("Confirmation = %+v", got.Confirmation)
+	}
+}
+
+func TestConfirmation_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    Confirmation
+		wantErr bool
+	}{
+		{name: "Redirect", conf: Confirmation{Type: Redirect, ReturnURL: "https://example.com"}},
+		{name: "Redirect without return_url", conf: Confirmation{Type: Redirect}, wantErr: true},
+		{name: "MobileApplication without return_url", conf: Confirmation{Type: MobileApplication}, wantErr: true},
+		{name: "QR without return_url", conf: Confirmation{Type: QR}},
+		{name: "Enforce with embedded", conf: Confirmation{Type: Embedded, Enforce: true}, wantErr: true},
+		{name: "Unknown type", conf: Confirmation{Type: "sms"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.conf.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
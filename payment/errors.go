@@ -0,0 +1,131 @@
+package payment
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Sentinel errors for API failures. YooKassaErrorResponse and HTTPError match them with errors.Is
+//
+// Example: if errors.Is(err, payment.ErrNotFound) { ... }
+//
+// Learn more at: https://yookassa.ru/en/developers/using-api/response-handling/response-format#errors
+var (
+	// ErrInvalidRequest is returned when request is malformed or not supported (HTTP 400)
+	ErrInvalidRequest = errors.New("invalid request")
+	// ErrInvalidCredentials is returned when shop id, secret key or OAuth token is wrong (HTTP 401)
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	// ErrForbidden is returned when the operation is not allowed for your store (HTTP 403)
+	ErrForbidden = errors.New("forbidden")
+	// ErrNotFound is returned when requested object doesn't exist (HTTP 404)
+	ErrNotFound = errors.New("not found")
+	// ErrTooManyRequests is returned when requests limit is exceeded (HTTP 429)
+	ErrTooManyRequests = errors.New("too many requests")
+	// ErrInternalServer is returned when YooKassa has technical issues (HTTP 500)
+	ErrInternalServer = errors.New("internal server error")
+	// ErrProcessing is returned when request is still being processed (HTTP 202).
+	// Repeat the request with the same idempotence key after retry_after
+	ErrProcessing = errors.New("request is being processed")
+)
+
+// codes maps YooKassa's error codes to sentinel errors
+var codes = map[string]error{
+	"invalid_request":       ErrInvalidRequest,
+	"not_supported":         ErrInvalidRequest,
+	"invalid_credentials":   ErrInvalidCredentials,
+	"forbidden":             ErrForbidden,
+	"not_found":             ErrNotFound,
+	"too_many_requests":     ErrTooManyRequests,
+	"internal_server_error": ErrInternalServer,
+}
+
+// statuses maps HTTP status codes to sentinel errors
+var statuses = map[int]error{
+	http.StatusAccepted:            ErrProcessing,
+	http.StatusBadRequest:          ErrInvalidRequest,
+	http.StatusUnauthorized:        ErrInvalidCredentials,
+	http.StatusForbidden:           ErrForbidden,
+	http.StatusNotFound:            ErrNotFound,
+	http.StatusTooManyRequests:     ErrTooManyRequests,
+	http.StatusInternalServerError: ErrInternalServer,
+}
+
+// YooKassaErrorResponse is used for handling error responses from YooKassa's endpoint.
+// It is also returned for HTTP 202 responses, when the request is still being processed
+type YooKassaErrorResponse struct {
+	Type string `json:"type"`
+	// ID is id of the error. Send it to YooKassa's support if you need help with the request
+	ID          string `json:"id"`
+	Code        string `json:"code"`
+	Description string `json:"description"`
+	Parameter   string `json:"parameter"`
+	// RetryAfter is recommended delay in milliseconds before the request is repeated
+	RetryAfter int `json:"retry_after,omitempty"`
+	// StatusCode is HTTP status code of the response
+	StatusCode int `json:"-"`
+	// IdempotenceKey is idempotence key the request was sent with
+	IdempotenceKey string `json:"-"`
+}
+
+func (y *YooKassaErrorResponse) Error() string {
+	if y.StatusCode == http.StatusAccepted {
+		return fmt.Sprintf("api is processing the request, retry after %d ms", y.RetryAfter)
+	}
+	if y.Parameter == "" {
+		return fmt.Sprintf("api returned error %s: %s", y.Code, y.Description)
+	}
+	return fmt.Sprintf("api returned error %s: %s (in parameter %s)", y.Code, y.Description, y.Parameter)
+}
+
+// Is reports whether the error matches one of the sentinel errors, like ErrNotFound
+func (y *YooKassaErrorResponse) Is(target error) bool {
+	if err, ok := codes[y.Code]; ok {
+		return err == target
+	}
+	return statuses[y.StatusCode] == target && target != nil
+}
+
+// RequestID returns id of the failed request, it is the same as ID
+func (y *YooKassaErrorResponse) RequestID() string {
+	return y.ID
+}
+
+// RetryAfterDuration returns RetryAfter as time.Duration
+func (y *YooKassaErrorResponse) RetryAfterDuration() time.Duration {
+	return time.Duration(y.RetryAfter) * time.Millisecond
+}
+
+// Temporary reports whether the request may succeed if it is repeated later
+func (y *YooKassaErrorResponse) Temporary() bool {
+	return y.Is(ErrTooManyRequests) || y.Is(ErrInternalServer) || y.Is(ErrProcessing) ||
+		y.StatusCode >= http.StatusInternalServerError
+}
+
+// HTTPError is returned when YooKassa's endpoint (or something in between) responds
+// with a non-successful HTTP status code and the body is not a YooKassaErrorResponse
+type HTTPError struct {
+	// StatusCode is HTTP status code of the response, like 502
+	StatusCode int
+	// Status is HTTP status line of the response, like "502 Bad Gateway"
+	Status string
+	// Body is raw response body
+	Body []byte
+	// IdempotenceKey is idempotence key the request was sent with
+	IdempotenceKey string
+}
+
+func (h *HTTPError) Error() string {
+	return fmt.Sprintf("api returned unexpected http status: %s", h.Status)
+}
+
+// Is reports whether the status code matches one of the sentinel errors, like ErrNotFound
+func (h *HTTPError) Is(target error) bool {
+	return statuses[h.StatusCode] == target && target != nil
+}
+
+// Temporary reports whether the request may succeed if it is repeated later
+func (h *HTTPError) Temporary() bool {
+	return h.StatusCode == http.StatusTooManyRequests || h.StatusCode >= http.StatusInternalServerError
+}
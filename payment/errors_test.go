@@ -0,0 +1,76 @@
+package payment
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestErrors_Is(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   error
+	}{
+		{name: "Invalid request", status: http.StatusBadRequest,
+			body: `{"type":"error","id":"1","code":"invalid_request","parameter":"amount"}`, want: ErrInvalidRequest},
+		{name: "Not supported", status: http.StatusBadRequest,
+			body: `{"type":"error","id":"1","code":"not_supported"}`, want: ErrInvalidRequest},
+		{name: "Invalid credentials", status: http.StatusUnauthorized,
+			body: `{"type":"error","id":"1","code":"invalid_credentials"}`, want: ErrInvalidCredentials},
+		{name: "Forbidden", status: http.StatusForbidden,
+			body: `{"type":"error","id":"1","code":"forbidden"}`, want: ErrForbidden},
+		{name: "Not found", status: http.StatusNotFound,
+			body: `{"type":"error","id":"1","code":"not_found"}`, want: ErrNotFound},
+		{name: "Too many requests", status: http.StatusTooManyRequests,
+			body: `{"type":"error","id":"1","code":"too_many_requests"}`, want: ErrTooManyRequests},
+		{name: "Internal server error", status: http.StatusInternalServerError,
+			body: `{"type":"error","id":"1","code":"internal_server_error","retry_after":1800}`, want: ErrInternalServer},
+		{name: "Unknown code", status: http.StatusNotFound,
+			body: `{"type":"error","id":"1","code":"something_new"}`, want: ErrNotFound},
+		{name: "Not JSON", status: http.StatusNotFound, body: `Not Found`, want: ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			_, err := NewClient(NewKassa()).SetBaseURL(server.URL).GetPayment("id")
+			if !errors.Is(err, tt.want) {
+				t.Errorf("GetPayment() error = %v, want %v", err, tt.want)
+			}
+			for _, other := range []error{ErrInvalidRequest, ErrInvalidCredentials, ErrForbidden,
+				ErrNotFound, ErrTooManyRequests, ErrInternalServer} {
+				if other != tt.want && errors.Is(err, other) {
+					t.Errorf("GetPayment() error = %v matches %v", err, other)
+				}
+			}
+		})
+	}
+}
+
+func TestYooKassaErrorResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"type":"error","id":"ab","code":"internal_server_error","retry_after":1800}`))
+	}))
+	defer server.Close()
+
+	_, err := NewClient(NewKassa()).SetBaseURL(server.URL).GetPayment("id")
+	var apiErr *YooKassaErrorResponse
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("GetPayment() error = %v, want *YooKassaErrorResponse", err)
+	}
+	if apiErr.StatusCode != http.StatusInternalServerError || apiErr.RequestID() != "ab" {
+		t.Errorf("StatusCode = %d, RequestID() = %q", apiErr.StatusCode, apiErr.RequestID())
+	}
+	if apiErr.RetryAfterDuration() != 1800*time.Millisecond || !apiErr.Temporary() {
+		t.Errorf("RetryAfterDuration() = %v, Temporary() = %v", apiErr.RetryAfterDuration(), apiErr.Temporary())
+	}
+}
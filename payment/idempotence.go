@@ -0,0 +1,76 @@
+package payment
+
+import (
+	"errors"
+	"github.com/hugmouse/goyookassa/internal/request"
+)
+
+// IdempotenceKeyMaxLength is maximum length of idempotence key accepted by YooKassa
+const IdempotenceKeyMaxLength = 64
+
+// ErrIdempotenceKeyTooLong is returned when idempotence key is longer than IdempotenceKeyMaxLength
+var ErrIdempotenceKeyTooLong = errors.New("idempotence key is too long")
+
+// RequestError is returned when request failed before YooKassa's response was received,
+// for example because of network error. Repeat the request with the same IdempotenceKey
+type RequestError struct {
+	// IdempotenceKey is idempotence key the request was sent with
+	IdempotenceKey string
+	// Err is the underlying error
+	Err error
+}
+
+func (r *RequestError) Error() string {
+	return r.Err.Error()
+}
+
+func (r *RequestError) Unwrap() error {
+	return r.Err
+}
+
+// NewIdempotenceKey returns a new random UUID v4 to be used as idempotence key
+func NewIdempotenceKey() string {
+	return request.NewIdempotenceKey()
+}
+
+// IdempotenceKeyOf returns idempotence key the failed request was sent with, or empty string if there is none
+func IdempotenceKeyOf(err error) string {
+	var apiErr *YooKassaErrorResponse
+	if errors.As(err, &apiErr) {
+		return apiErr.IdempotenceKey
+	}
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.IdempotenceKey
+	}
+	var reqErr *RequestError
+	if errors.As(err, &reqErr) {
+		return reqErr.IdempotenceKey
+	}
+	return ""
+}
+
+// ensureIdempotenceKey generates idempotence key if it is not set
+func ensureIdempotenceKey(key *string) {
+	if *key == "" {
+		*key = NewIdempotenceKey()
+	}
+}
+
+// withIdempotenceKey attaches idempotence key to the error
+func withIdempotenceKey(err error, key string) error {
+	if err == nil || key == "" {
+		return err
+	}
+	var apiErr *YooKassaErrorResponse
+	if errors.As(err, &apiErr) {
+		apiErr.IdempotenceKey = key
+		return err
+	}
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		httpErr.IdempotenceKey = key
+		return err
+	}
+	return &RequestError{IdempotenceKey: key, Err: err}
+}
@@ -0,0 +1,96 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"github.com/hugmouse/goyookassa/consts"
+	"github.com/shopspring/decimal"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestNewIdempotenceKey(t *testing.T) {
+	uuid := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	first, second := NewIdempotenceKey(), NewIdempotenceKey()
+	if !uuid.MatchString(first) {
+		t.Errorf("NewIdempotenceKey() = %q, want UUID v4", first)
+	}
+	if first == second {
+		t.Errorf("NewIdempotenceKey() returned %q twice", first)
+	}
+}
+
+func TestPayment_DoIdempotenceKey(t *testing.T) {
+	var sent []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent = append(sent, r.Header.Get(consts.IdempotentHeader))
+		if len(sent) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"type":"error","id":"1","code":"internal_server_error"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"some-id","status":"pending"}`))
+	}))
+	defer server.Close()
+
+	p := NewPayment().
+		SetClient(NewClient(NewKassa()).SetBaseURL(server.URL)).
+		SetAmount(decimal.NewFromInt(1), "RUB")
+	_, err := p.Do()
+	if err == nil {
+		t.Fatal("Do() error = nil")
+	}
+	if sent[0] == "" || IdempotenceKeyOf(err) != sent[0] {
+		t.Errorf("sent %q, IdempotenceKeyOf() = %q", sent[0], IdempotenceKeyOf(err))
+	}
+	if p.IdempotenceKey != "" {
+		t.Errorf("IdempotenceKey = %q, want it untouched", p.IdempotenceKey)
+	}
+
+	got, err := p.SetIdempotenceKey(IdempotenceKeyOf(err)).Do()
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	if sent[1] != sent[0] || got.IdempotenceKey != sent[0] {
+		t.Errorf("repeated request key = %q, response key = %q, want %q", sent[1], got.IdempotenceKey, sent[0])
+	}
+
+	p.SetIdempotenceKey("")
+	first, err := p.Do()
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	second, err := p.Do()
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	if sent[2] == sent[3] || first.IdempotenceKey != sent[2] || second.IdempotenceKey != sent[3] {
+		t.Errorf("sent %q, response keys %q and %q, want distinct keys",
+			sent[2:], first.IdempotenceKey, second.IdempotenceKey)
+	}
+}
+
+func TestClient_SendIdempotenceKey(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+	client := NewClient(NewKassa()).SetBaseURL(server.URL)
+
+	err := client.Send(context.Background(), http.MethodPost, "payments", strings.Repeat("k", 65), struct{}{}, nil)
+	if !errors.Is(err, ErrIdempotenceKeyTooLong) || requests != 0 {
+		t.Errorf("Send() error = %v, requests = %d, want %v", err, requests, ErrIdempotenceKeyTooLong)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = client.Send(ctx, http.MethodPost, "payments", "key", struct{}{}, nil)
+	var reqErr *RequestError
+	if !errors.As(err, &reqErr) || reqErr.IdempotenceKey != "key" || !errors.Is(err, context.Canceled) {
+		t.Errorf("Send() error = %v, want *RequestError wrapping %v", err, context.Canceled)
+	}
+}
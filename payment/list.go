@@ -0,0 +1,250 @@
+package payment
+
+import (
+	"context"
+	"fmt"
+	"github.com/hugmouse/goyookassa/internal/request"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ListMaxLimit is the maximum size of a list page
+const ListMaxLimit = 100
+
+// ListFilter is used to filter and paginate payments list
+//
+// Learn more: https://yookassa.ru/en/developers/api#get_payments_list
+type ListFilter struct {
+	// CreatedAtGte filters payments created at the specified time or later
+	CreatedAtGte time.Time
+	// CreatedAtGt filters payments created later than the specified time
+	CreatedAtGt time.Time
+	// CreatedAtLte filters payments created at the specified time or earlier
+	CreatedAtLte time.Time
+	// CreatedAtLt filters payments created earlier than the specified time
+	CreatedAtLt time.Time
+	// CapturedAtGte filters payments captured at the specified time or later
+	CapturedAtGte time.Time
+	// CapturedAtGt filters payments captured later than the specified time
+	CapturedAtGt time.Time
+	// CapturedAtLte filters payments captured at the specified time or earlier
+	CapturedAtLte time.Time
+	// CapturedAtLt filters payments captured earlier than the specified time
+	CapturedAtLt time.Time
+	// PaymentMethod filters payments by payment method type, like bank_card
+	PaymentMethod string
+	// Status filters payments by status
+	Status Status
+	// Limit is size of the page (1 to 100, 10 by default)
+	Limit int
+	// Cursor is NextCursor of the previous page
+	Cursor string
+}
+
+// PaymentIterator iterates over every payment that matches the filter,
+// transparently following List.NextCursor
+//
+// Example:
+//
+//	it := client.IteratePayments(ctx, filter)
+//	for it.Next() {
+//		fmt.Println(it.Payment().ID)
+//	}
+//	if err := it.Err(); err != nil {
+//		// handle error
+//	}
+type PaymentIterator struct {
+	client  *Client
+	ctx     context.Context
+	filter  ListFilter
+	page    []PaymentObject
+	current *PaymentObject
+	started bool
+	err     error
+}
+
+// NewListFilter creates and initializes a new ListFilter
+func NewListFilter() *ListFilter {
+	return &ListFilter{}
+}
+
+// SetCreatedAtGte filters payments created at t or later
+func (f *ListFilter) SetCreatedAtGte(t time.Time) *ListFilter {
+	f.CreatedAtGte = t
+	return f
+}
+
+// SetCreatedAtGt filters payments created later than t
+func (f *ListFilter) SetCreatedAtGt(t time.Time) *ListFilter {
+	f.CreatedAtGt = t
+	return f
+}
+
+// SetCreatedAtLte filters payments created at t or earlier
+func (f *ListFilter) SetCreatedAtLte(t time.Time) *ListFilter {
+	f.CreatedAtLte = t
+	return f
+}
+
+// SetCreatedAtLt filters payments created earlier than t
+func (f *ListFilter) SetCreatedAtLt(t time.Time) *ListFilter {
+	f.CreatedAtLt = t
+	return f
+}
+
+// SetCapturedAtGte filters payments captured at t or later
+func (f *ListFilter) SetCapturedAtGte(t time.Time) *ListFilter {
+	f.CapturedAtGte = t
+	return f
+}
+
+// SetCapturedAtGt filters payments captured later than t
+func (f *ListFilter) SetCapturedAtGt(t time.Time) *ListFilter {
+	f.CapturedAtGt = t
+	return f
+}
+
+// SetCapturedAtLte filters payments captured at t or earlier
+func (f *ListFilter) SetCapturedAtLte(t time.Time) *ListFilter {
+	f.CapturedAtLte = t
+	return f
+}
+
+// SetCapturedAtLt filters payments captured earlier than t
+func (f *ListFilter) SetCapturedAtLt(t time.Time) *ListFilter {
+	f.CapturedAtLt = t
+	return f
+}
+
+// SetPaymentMethod filters payments by payment method type
+func (f *ListFilter) SetPaymentMethod(method string) *ListFilter {
+	f.PaymentMethod = method
+	return f
+}
+
+// SetStatus filters payments by status
+func (f *ListFilter) SetStatus(status Status) *ListFilter {
+	f.Status = status
+	return f
+}
+
+// SetLimit sets size of the page (1 to 100)
+func (f *ListFilter) SetLimit(limit int) *ListFilter {
+	f.Limit = limit
+	return f
+}
+
+// SetCursor sets cursor of the page, use List.NextCursor of the previous page
+func (f *ListFilter) SetCursor(cursor string) *ListFilter {
+	f.Cursor = cursor
+	return f
+}
+
+// Validate checks that Limit is from 1 to ListMaxLimit, if it is set.
+// The error is ValidationErrors with limit parameter
+func (f *ListFilter) Validate() error {
+	var errs ValidationErrors
+	if f != nil && (f.Limit < 0 || f.Limit > ListMaxLimit) {
+		errs.Add("limit", fmt.Sprintf("limit must be from 1 to %d", ListMaxLimit))
+	}
+	return errs.Err()
+}
+
+// Query returns filter as URL query parameters
+func (f *ListFilter) Query() url.Values {
+	if f == nil {
+		return url.Values{}
+	}
+	q := request.ListParams{
+		CreatedAtGte: f.CreatedAtGte,
+		CreatedAtGt:  f.CreatedAtGt,
+		CreatedAtLte: f.CreatedAtLte,
+		CreatedAtLt:  f.CreatedAtLt,
+		Status:       string(f.Status),
+		Limit:        f.Limit,
+		Cursor:       f.Cursor,
+	}.Query()
+	request.SetTime(q, "captured_at.gte", f.CapturedAtGte)
+	request.SetTime(q, "captured_at.gt", f.CapturedAtGt)
+	request.SetTime(q, "captured_at.lte", f.CapturedAtLte)
+	request.SetTime(q, "captured_at.lt", f.CapturedAtLt)
+	if f.PaymentMethod != "" {
+		q.Set("payment_method", f.PaymentMethod)
+	}
+	return q
+}
+
+// ListPayments returns a page of payments that match the filter. The filter may be nil
+//
+// API errors are returned as *YooKassaErrorResponse, other unsuccessful responses as *HTTPError.
+func (c *Client) ListPayments(filter *ListFilter) (*List, error) {
+	return c.ListPaymentsContext(context.Background(), filter)
+}
+
+// ListPaymentsContext returns a page of payments that match the filter using the provided context.
+// The filter is validated first, unless Client.SkipValidation is set
+func (c *Client) ListPaymentsContext(ctx context.Context, filter *ListFilter) (*List, error) {
+	if !c.SkipValidation {
+		if err := filter.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	path := "payments"
+	if q := filter.Query(); len(q) != 0 {
+		path += "?" + q.Encode()
+	}
+
+	listFromJSON := new(List)
+	if err := c.Send(ctx, http.MethodGet, path, "", nil, listFromJSON); err != nil {
+		return nil, err
+	}
+	return listFromJSON, nil
+}
+
+// IteratePayments returns an iterator over every payment that matches the filter. The filter may be nil
+//
+// Pages are requested lazily, when the previous page is exhausted.
+func (c *Client) IteratePayments(ctx context.Context, filter *ListFilter) *PaymentIterator {
+	it := &PaymentIterator{client: c, ctx: ctx}
+	if filter != nil {
+		it.filter = *filter
+	}
+	return it
+}
+
+// Next advances the iterator to the next payment, fetching the next page if needed.
+// It returns false when there are no more payments or an error occurred
+func (it *PaymentIterator) Next() bool {
+	for len(it.page) == 0 {
+		if it.err != nil || (it.started && it.filter.Cursor == "") {
+			it.current = nil
+			return false
+		}
+		it.started = true
+
+		list, err := it.client.ListPaymentsContext(it.ctx, &it.filter)
+		if err != nil {
+			it.err = err
+			it.current = nil
+			return false
+		}
+		it.page = list.Items
+		it.filter.Cursor = list.NextCursor
+	}
+
+	it.current = &it.page[0]
+	it.page = it.page[1:]
+	return true
+}
+
+// Payment returns the current payment
+func (it *PaymentIterator) Payment() *PaymentObject {
+	return it.current
+}
+
+// Err returns the first error that was encountered by the iterator
+func (it *PaymentIterator) Err() error {
+	return it.err
+}
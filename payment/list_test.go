@@ -0,0 +1,84 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestListFilter_Query(t *testing.T) {
+	f := NewListFilter().
+		SetCreatedAtGte(time.Date(2021, 8, 1, 0, 0, 0, 0, time.UTC)).
+		SetCapturedAtLt(time.Date(2021, 8, 2, 3, 0, 0, 0, time.FixedZone("MSK", 3*60*60))).
+		SetPaymentMethod("bank_card").
+		SetStatus(Succeeded).
+		SetLimit(100)
+
+	want := "captured_at.lt=2021-08-02T00%3A00%3A00.000Z&created_at.gte=2021-08-01T00%3A00%3A00.000Z" +
+		"&limit=100&payment_method=bank_card&status=succeeded"
+	if got := f.Query().Encode(); got != want {
+		t.Errorf("Query() = %q, want %q", got, want)
+	}
+}
+
+func TestClient_IteratePayments(t *testing.T) {
+	pages := map[string]string{
+		"":   `{"type":"list","items":[{"id":"1"},{"id":"2"}],"next_cursor":"c1"}`,
+		"c1": `{"type":"list","items":[],"next_cursor":"c2"}`,
+		"c2": `{"type":"list","items":[{"id":"3"}]}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("status"); got != "succeeded" {
+			t.Errorf("status = %q, want %q", got, "succeeded")
+		}
+		_, _ = w.Write([]byte(pages[r.URL.Query().Get("cursor")]))
+	}))
+	defer server.Close()
+
+	it := NewClient(NewKassa()).SetBaseURL(server.URL).
+		IteratePayments(context.Background(), NewListFilter().SetStatus(Succeeded))
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Payment().ID)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("Err() = %v", err)
+	}
+	if len(ids) != 3 || ids[0] != "1" || ids[2] != "3" {
+		t.Errorf("ids = %v, want [1 2 3]", ids)
+	}
+}
+
+func TestClient_ListPaymentsValidation(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(`{"type":"list","items":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(NewKassa()).SetBaseURL(server.URL)
+	for _, limit := range []int{-1, ListMaxLimit + 1} {
+		_, err := client.ListPayments(NewListFilter().SetLimit(limit))
+		if got := parameters(err); !errors.Is(err, ErrValidation) || !reflect.DeepEqual(got, []string{"limit"}) {
+			t.Errorf("ListPayments(limit %d) error = %v, want %v", limit, err, ErrValidation)
+		}
+	}
+	if requests != 0 {
+		t.Errorf("requests = %d, want 0", requests)
+	}
+
+	if _, err := client.ListPayments(NewListFilter().SetLimit(ListMaxLimit)); err != nil {
+		t.Errorf("ListPayments() error = %v", err)
+	}
+	if _, err := client.SetSkipValidation(true).ListPayments(NewListFilter().SetLimit(-1)); err != nil {
+		t.Errorf("ListPayments() error = %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("requests = %d, want 2", requests)
+	}
+}
@@ -0,0 +1,73 @@
+package payment
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"unicode/utf8"
+)
+
+const (
+	// MetadataMaxKeys is maximum number of keys in Metadata
+	MetadataMaxKeys = 16
+	// MetadataMaxKeyLength is maximum length of Metadata key
+	MetadataMaxKeyLength = 32
+	// MetadataMaxValueLength is maximum length of Metadata value
+	MetadataMaxValueLength = 512
+)
+
+// Metadata is any additional data you might require for processing payments.
+// It is sent as key-value pairs and returned back in responses from YooKassa
+//
+// Learn more at: https://yookassa.ru/en/developers/using-api/basics#metadata
+type Metadata map[string]string
+
+// Validate checks that metadata fits YooKassa's limits, the error is ValidationErrors
+// with keys as parameters
+func (m Metadata) Validate() error {
+	var errs ValidationErrors
+	if len(m) > MetadataMaxKeys {
+		errs.Add("", fmt.Sprintf("metadata has %d keys, maximum is %d", len(m), MetadataMaxKeys))
+	}
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if utf8.RuneCountInString(key) > MetadataMaxKeyLength {
+			errs.Add(key, fmt.Sprintf("key is longer than %d characters", MetadataMaxKeyLength))
+		}
+		if utf8.RuneCountInString(m[key]) > MetadataMaxValueLength {
+			errs.Add(key, fmt.Sprintf("value is longer than %d characters", MetadataMaxValueLength))
+		}
+	}
+	return errs.Err()
+}
+
+// UnmarshalJSON decodes metadata. Values that are not strings (like numbers set by CMS plugins)
+// are kept as their JSON representation
+func (m *Metadata) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if raw == nil {
+		*m = nil
+		return nil
+	}
+
+	metadata := make(Metadata, len(raw))
+	for key, value := range raw {
+		if string(value) == "null" {
+			continue
+		}
+		var s string
+		if err := json.Unmarshal(value, &s); err != nil {
+			s = string(value)
+		}
+		metadata[key] = s
+	}
+	*m = metadata
+	return nil
+}
@@ -0,0 +1,47 @@
+package payment
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMetadata_Validate(t *testing.T) {
+	tooMany := Metadata{}
+	for i := 0; i < MetadataMaxKeys+1; i++ {
+		tooMany[strings.Repeat("k", i+1)] = "v"
+	}
+
+	tests := []struct {
+		name     string
+		metadata Metadata
+		wantErr  bool
+	}{
+		{name: "Nil", metadata: nil},
+		{name: "Order id", metadata: Metadata{"order_id": "37"}},
+		{name: "Too many keys", metadata: tooMany, wantErr: true},
+		{name: "Long key", metadata: Metadata{strings.Repeat("k", 33): "v"}, wantErr: true},
+		{name: "Long value", metadata: Metadata{"k": strings.Repeat("в", 513)}, wantErr: true},
+		{name: "Unicode value within limit", metadata: Metadata{"k": strings.Repeat("в", 512)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.metadata.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMetadata_UnmarshalJSON(t *testing.T) {
+	var got PaymentObject
+	err := json.Unmarshal([]byte(`{"metadata":{"order_id":"37","cms_version":2,"empty":null}}`), &got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Metadata{"order_id": "37", "cms_version": "2"}
+	if !reflect.DeepEqual(got.Metadata, want) {
+		t.Errorf("Metadata = %v, want %v", got.Metadata, want)
+	}
+}
@@ -0,0 +1,341 @@
+package payment
+
+import "encoding/json"
+
+// MethodType is payment method code
+//
+// Learn more at: https://yookassa.ru/en/developers/payment-acceptance/getting-started/payment-methods
+type MethodType string
+
+const (
+	MethodBankCard      MethodType = "bank_card"
+	MethodSBP           MethodType = "sbp"
+	MethodYooMoney      MethodType = "yoo_money"
+	MethodSberbank      MethodType = "sberbank"
+	MethodTinkoffBank   MethodType = "tinkoff_bank"
+	MethodMobileBalance MethodType = "mobile_balance"
+	MethodB2BSberbank   MethodType = "b2b_sberbank"
+	MethodInstallments  MethodType = "installments"
+	MethodSberLoan      MethodType = "sber_loan"
+	MethodCash          MethodType = "cash"
+	MethodQiwi          MethodType = "qiwi"
+	MethodAlfabank      MethodType = "alfabank"
+	MethodApplePay      MethodType = "apple_pay"
+	MethodGooglePay     MethodType = "google_pay"
+)
+
+// MethodData is payment_method_data of Payment, one of: BankCardData, SBPData, YooMoneyData,
+// SberbankData, TinkoffBankData, MobileBalanceData, B2BSberbankData, InstallmentsData, SberLoanData,
+// CashData, QiwiData, AlfabankData, ApplePayData, GooglePayData or RawMethodData.
+// The type field is added when the data is encoded
+//
+// Learn more: https://yookassa.ru/en/developers/api#create_payment_payment_method_data
+type MethodData interface {
+	MethodType() MethodType
+}
+
+// BankCardData is bank card payment data.
+// Card must be set only if you're PCI DSS compliant and collect card data yourself
+type BankCardData struct {
+	Card *CardData `json:"card,omitempty"`
+}
+
+// CardData is raw bank card data
+type CardData struct {
+	// Number is bank card number
+	Number string `json:"number"`
+	// ExpiryYear is expiration year, YYYY
+	ExpiryYear string `json:"expiry_year"`
+	// ExpiryMonth is expiration month, MM
+	ExpiryMonth string `json:"expiry_month"`
+	// CSC is card security code, CVC2 or CVV2
+	CSC string `json:"csc,omitempty"`
+	// Cardholder is cardholder's name
+	Cardholder string `json:"cardholder,omitempty"`
+}
+
+// SBPData is Faster Payments System (SBP) payment data
+type SBPData struct{}
+
+// YooMoneyData is YooMoney wallet payment data
+type YooMoneyData struct{}
+
+// SberbankData is SberPay payment data. Phone is required for the external confirmation scenario
+type SberbankData struct {
+	Phone string `json:"phone,omitempty"`
+}
+
+// TinkoffBankData is T-Pay payment data
+type TinkoffBankData struct{}
+
+// MobileBalanceData is mobile balance payment data
+type MobileBalanceData struct {
+	// Phone is phone number the payment is charged from, specified in the ITU-T E.164 format
+	Phone string `json:"phone"`
+}
+
+// B2BSberbankData is SberBusinessOnline payment data
+type B2BSberbankData struct {
+	// PaymentPurpose is purpose of payment (up to 210 characters)
+	PaymentPurpose string `json:"payment_purpose"`
+	// VatData is VAT information
+	VatData VatData `json:"vat_data"`
+}
+
+// VatData is VAT information of B2BSberbankData
+type VatData struct {
+	// Type is VAT calculation method: calculated, mixed or untaxed
+	Type string `json:"type"`
+	// Rate is VAT rate in percent, required for calculated type
+	Rate string `json:"rate,omitempty"`
+	// Amount is VAT amount, required for calculated and mixed types
+	Amount *Amount `json:"amount,omitempty"`
+}
+
+// InstallmentsData is "Installments" payment data
+type InstallmentsData struct{}
+
+// SberLoanData is "Loans from SberBank" payment data
+type SberLoanData struct{}
+
+// CashData is cash via payment kiosks payment data
+type CashData struct {
+	Phone string `json:"phone,omitempty"`
+}
+
+// QiwiData is QIWI Wallet payment data
+type QiwiData struct {
+	Phone string `json:"phone,omitempty"`
+}
+
+// AlfabankData is Alfa-Click payment data. Login is required for the external confirmation scenario
+type AlfabankData struct {
+	Login string `json:"login,omitempty"`
+}
+
+// ApplePayData is Apple Pay payment data
+type ApplePayData struct {
+	// PaymentData is contents of paymentData field of PKPaymentToken object, Base64 encoded
+	PaymentData string `json:"payment_data"`
+}
+
+// GooglePayData is Google Pay payment data
+type GooglePayData struct {
+	// PaymentMethodToken is cryptogram Payment Token Cryptography for paying with Google Pay
+	PaymentMethodToken string `json:"payment_method_token"`
+	// GoogleTransactionID is unique transaction id issued by Google
+	GoogleTransactionID string `json:"google_transaction_id"`
+}
+
+// RawMethodData is payment method data of any type, use it for methods and fields the library doesn't support yet.
+// Fields are sent as is, with the type field set to Type
+type RawMethodData struct {
+	Type   MethodType
+	Fields map[string]interface{}
+}
+
+// Method is payment method used for the payment, returned in PaymentObject.
+// Type tells which of the method-specific fields are set
+//
+// Learn more: https://yookassa.ru/en/developers/api#payment_object_payment_method
+type Method struct {
+	Type  MethodType `json:"type"`
+	ID    string     `json:"id"`
+	Saved bool       `json:"saved"`
+	Title string     `json:"title,omitempty"`
+	// Card is set for bank_card
+	Card *Card `json:"card,omitempty"`
+	// Phone is set for sberbank, mobile_balance, cash and qiwi
+	Phone string `json:"phone,omitempty"`
+	// AccountNumber is set for yoo_money
+	AccountNumber string `json:"account_number,omitempty"`
+	// Login is set for alfabank
+	Login string `json:"login,omitempty"`
+	// PaymentPurpose and VatData are set for b2b_sberbank
+	PaymentPurpose string   `json:"payment_purpose,omitempty"`
+	VatData        *VatData `json:"vat_data,omitempty"`
+	// PayerBankDetails is set for b2b_sberbank and sbp
+	PayerBankDetails *PayerBankDetails `json:"payer_bank_details,omitempty"`
+	// SBPOperationID is set for sbp
+	SBPOperationID string `json:"sbp_operation_id,omitempty"`
+	// LoanOption and DiscountAmount are set for sber_loan
+	LoanOption     string  `json:"loan_option,omitempty"`
+	DiscountAmount *Amount `json:"discount_amount,omitempty"`
+}
+
+// Card is bank card details returned in Method
+type Card struct {
+	First6        string `json:"first6"`
+	Last4         string `json:"last4"`
+	ExpiryMonth   string `json:"expiry_month"`
+	ExpiryYear    string `json:"expiry_year"`
+	CardType      string `json:"card_type"`
+	IssuerCountry string `json:"issuer_country"`
+	IssuerName    string `json:"issuer_name"`
+	// Source is source of the card data: apple_pay, google_pay or mir_pay
+	Source string `json:"source,omitempty"`
+}
+
+// PayerBankDetails is bank details of the payer
+type PayerBankDetails struct {
+	// BankID and BIC are set for sbp
+	BankID string `json:"bank_id,omitempty"`
+	BIC    string `json:"bic,omitempty"`
+	// The rest of the fields are set for b2b_sberbank
+	FullName   string `json:"full_name,omitempty"`
+	ShortName  string `json:"short_name,omitempty"`
+	Address    string `json:"address,omitempty"`
+	INN        string `json:"inn,omitempty"`
+	KPP        string `json:"kpp,omitempty"`
+	BankName   string `json:"bank_name,omitempty"`
+	BankBranch string `json:"bank_branch,omitempty"`
+	BankBIK    string `json:"bank_bik,omitempty"`
+	Account    string `json:"account,omitempty"`
+}
+
+// Data returns method-specific data of the payment method, for example SberbankData with Phone
+// for sberbank. It returns nil for unknown methods
+func (m *Method) Data() MethodData {
+	switch m.Type {
+	case MethodBankCard:
+		return BankCardData{}
+	case MethodSBP:
+		return SBPData{}
+	case MethodYooMoney:
+		return YooMoneyData{}
+	case MethodSberbank:
+		return SberbankData{Phone: m.Phone}
+	case MethodTinkoffBank:
+		return TinkoffBankData{}
+	case MethodMobileBalance:
+		return MobileBalanceData{Phone: m.Phone}
+	case MethodB2BSberbank:
+		data := B2BSberbankData{PaymentPurpose: m.PaymentPurpose}
+		if m.VatData != nil {
+			data.VatData = *m.VatData
+		}
+		return data
+	case MethodInstallments:
+		return InstallmentsData{}
+	case MethodSberLoan:
+		return SberLoanData{}
+	case MethodCash:
+		return CashData{Phone: m.Phone}
+	case MethodQiwi:
+		return QiwiData{Phone: m.Phone}
+	case MethodAlfabank:
+		return AlfabankData{Login: m.Login}
+	case MethodApplePay:
+		return ApplePayData{}
+	case MethodGooglePay:
+		return GooglePayData{}
+	}
+	return nil
+}
+
+// typed adds type field to encoded method data
+func typed(t MethodType, data interface{}) ([]byte, error) {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil, err
+	}
+	if fields == nil {
+		fields = map[string]json.RawMessage{}
+	}
+	fields["type"], _ = json.Marshal(t)
+	return json.Marshal(fields)
+}
+
+func (BankCardData) MethodType() MethodType      { return MethodBankCard }
+func (SBPData) MethodType() MethodType           { return MethodSBP }
+func (YooMoneyData) MethodType() MethodType      { return MethodYooMoney }
+func (SberbankData) MethodType() MethodType      { return MethodSberbank }
+func (TinkoffBankData) MethodType() MethodType   { return MethodTinkoffBank }
+func (MobileBalanceData) MethodType() MethodType { return MethodMobileBalance }
+func (B2BSberbankData) MethodType() MethodType   { return MethodB2BSberbank }
+func (InstallmentsData) MethodType() MethodType  { return MethodInstallments }
+func (SberLoanData) MethodType() MethodType      { return MethodSberLoan }
+func (CashData) MethodType() MethodType          { return MethodCash }
+func (QiwiData) MethodType() MethodType          { return MethodQiwi }
+func (AlfabankData) MethodType() MethodType      { return MethodAlfabank }
+func (ApplePayData) MethodType() MethodType      { return MethodApplePay }
+func (GooglePayData) MethodType() MethodType     { return MethodGooglePay }
+func (d RawMethodData) MethodType() MethodType   { return d.Type }
+
+func (d BankCardData) MarshalJSON() ([]byte, error) {
+	type data BankCardData
+	return typed(d.MethodType(), data(d))
+}
+
+func (d SBPData) MarshalJSON() ([]byte, error) {
+	type data SBPData
+	return typed(d.MethodType(), data(d))
+}
+
+func (d YooMoneyData) MarshalJSON() ([]byte, error) {
+	type data YooMoneyData
+	return typed(d.MethodType(), data(d))
+}
+
+func (d SberbankData) MarshalJSON() ([]byte, error) {
+	type data SberbankData
+	return typed(d.MethodType(), data(d))
+}
+
+func (d TinkoffBankData) MarshalJSON() ([]byte, error) {
+	type data TinkoffBankData
+	return typed(d.MethodType(), data(d))
+}
+
+func (d MobileBalanceData) MarshalJSON() ([]byte, error) {
+	type data MobileBalanceData
+	return typed(d.MethodType(), data(d))
+}
+
+func (d B2BSberbankData) MarshalJSON() ([]byte, error) {
+	type data B2BSberbankData
+	return typed(d.MethodType(), data(d))
+}
+
+func (d InstallmentsData) MarshalJSON() ([]byte, error) {
+	type data InstallmentsData
+	return typed(d.MethodType(), data(d))
+}
+
+func (d SberLoanData) MarshalJSON() ([]byte, error) {
+	type data SberLoanData
+	return typed(d.MethodType(), data(d))
+}
+
+func (d CashData) MarshalJSON() ([]byte, error) {
+	type data CashData
+	return typed(d.MethodType(), data(d))
+}
+
+func (d QiwiData) MarshalJSON() ([]byte, error) {
+	type data QiwiData
+	return typed(d.MethodType(), data(d))
+}
+
+func (d AlfabankData) MarshalJSON() ([]byte, error) {
+	type data AlfabankData
+	return typed(d.MethodType(), data(d))
+}
+
+func (d ApplePayData) MarshalJSON() ([]byte, error) {
+	type data ApplePayData
+	return typed(d.MethodType(), data(d))
+}
+
+func (d GooglePayData) MarshalJSON() ([]byte, error) {
+	type data GooglePayData
+	return typed(d.MethodType(), data(d))
+}
+
+func (d RawMethodData) MarshalJSON() ([]byte, error) {
+	return typed(d.Type, d.Fields)
+}
@@ -0,0 +1,67 @@
+package payment
+
+import (
+	"encoding/json"
+	"github.com/shopspring/decimal"
+	"testing"
+)
+
+func TestMethodData_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		data MethodData
+		want string
+	}{
+		{"SBP", SBPData{}, `{"type":"sbp"}`},
+		{"Sberbank", SberbankData{Phone: "79000000000"}, `{"phone":"79000000000","type":"sberbank"}`},
+		{"Alfabank", AlfabankData{Login: "user"}, `{"login":"user","type":"alfabank"}`},
+		{
+			"Bank card",
+			BankCardData{Card: &CardData{Number: "5555555555554444", ExpiryYear: "2030", ExpiryMonth: "07", CSC: "123"}},
+			`{"card":{"number":"5555555555554444","expiry_year":"2030","expiry_month":"07","csc":"123"},"type":"bank_card"}`,
+		},
+		{
+			"B2B Sberbank",
+			B2BSberbankData{PaymentPurpose: "Order 1", VatData: VatData{Type: "calculated", Rate: "20",
+				Amount: &Amount{Value: decimal.RequireFromString("20.00"), Currency: "RUB"}}},
+			`{"payment_purpose":"Order 1","type":"b2b_sberbank",` +
+				`"vat_data":{"type":"calculated","rate":"20","amount":{"value":"20.00","currency":"RUB"}}}`,
+		},
+		{"Apple Pay", ApplePayData{PaymentData: "data"}, `{"payment_data":"data","type":"apple_pay"}`},
+		{
+			"Raw",
+			RawMethodData{Type: "electronic_certificate", Fields: map[string]interface{}{"type": "x", "id": "1"}},
+			`{"id":"1","type":"electronic_certificate"}`,
+		},
+		{"Raw without fields", RawMethodData{Type: "mir_pay"}, `{"type":"mir_pay"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(NewPayment().SetPaymentMethodData(tt.data).PaymentMethodData)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Marshal() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMethod_Data(t *testing.T) {
+	var p PaymentObject
+	err := json.Unmarshal([]byte(`{"id":"1","payment_method":{"type":"sberbank","id":"2","saved":false,`+
+		`"phone":"79000000000","title":"SberPay"}}`), &p)
+	if err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	data, ok := p.PaymentMethod.Data().(SberbankData)
+	if !ok || data.Phone != "79000000000" {
+		t.Errorf("Data() = %#v", p.PaymentMethod.Data())
+	}
+
+	unknown := Method{Type: "unknown"}
+	if got := unknown.Data(); got != nil {
+		t.Errorf("Data() = %#v, want nil", got)
+	}
+}
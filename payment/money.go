@@ -0,0 +1,269 @@
+package payment
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/shopspring/decimal"
+	"regexp"
+	"strings"
+)
+
+// ErrCurrencyMismatch is returned when operation is applied to Money of different currencies
+var ErrCurrencyMismatch = errors.New("currency mismatch")
+
+// ErrMoneyPrecision is returned when Money value has more decimal places than the currency's minor units
+var ErrMoneyPrecision = errors.New("money value is more precise than the currency allows")
+
+// minorUnits maps ISO-4217 currency codes to number of digits after the decimal separator,
+// currencies missing from the map have 2 digits
+var minorUnits = map[string]int32{
+	"BHD": 3, "BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "IQD": 3, "ISK": 0, "JOD": 3,
+	"JPY": 0, "KMF": 0, "KRW": 0, "KWD": 3, "LYD": 3, "OMR": 3, "PYG": 0, "RWF": 0,
+	"TND": 3, "UGX": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+}
+
+// moneyValue is format of Money value accepted by YooKassa, like "100.00"
+var moneyValue = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+
+// MinorUnits returns number of digits after the decimal separator of ISO-4217 currency, like 2 for RUB
+func MinorUnits(currency string) int32 {
+	if units, ok := minorUnits[strings.ToUpper(currency)]; ok {
+		return units
+	}
+	return 2
+}
+
+// Money is amount of money in ISO-4217 currency
+//
+// It is encoded as {"value": "100.00", "currency": "RUB"}, with value padded to the currency's minor units.
+// Values with more decimal places are not rounded silently, use NewMoney or Round for that.
+//
+// Learn more: https://yookassa.ru/en/developers/api#create_payment_amount
+type Money struct {
+	// Value is how much money you want to get from someone
+	Value decimal.Decimal `json:"value"`
+	// Currency is three letter currency code (ex: RUB)
+	Currency string `json:"currency,omitempty"`
+}
+
+// Amount (payment amount) is Money used in requests and responses
+//
+// Sometimes YooMoney's partners charge additional commission from the users that is not included in this amount.
+type Amount = Money
+
+// AmountFromResponse is amount returned by YooKassa's endpoint
+//
+// Deprecated: use Money
+type AmountFromResponse = Money
+
+// NewMoney creates Money rounded to the currency's minor units
+//
+// Example: payment.NewMoney(decimal.RequireFromString("100.00"), "RUB")
+func NewMoney(value decimal.Decimal, currency string) Money {
+	return Money{Value: value, Currency: currency}.Round()
+}
+
+// NewMoneyFromMinor creates Money from amount of minor units, like kopeks for RUB
+//
+// Example: payment.NewMoneyFromMinor(10050, "RUB") is 100.50 RUB
+func NewMoneyFromMinor(units int64, currency string) Money {
+	return Money{Value: decimal.New(units, -MinorUnits(currency)), Currency: currency}
+}
+
+// ParseMoney parses value in "100.00" format and creates Money rounded to the currency's minor units
+func ParseMoney(value, currency string) (Money, error) {
+	if !moneyValue.MatchString(value) {
+		return Money{}, fmt.Errorf("invalid money value %q", value)
+	}
+	d, err := decimal.NewFromString(value)
+	if err != nil {
+		return Money{}, err
+	}
+	return NewMoney(d, currency), nil
+}
+
+// Round returns m rounded to the currency's minor units
+func (m Money) Round() Money {
+	return Money{Value: m.Value.Round(MinorUnits(m.Currency)), Currency: m.Currency}
+}
+
+// Minor returns amount of minor units of m, like kopeks for RUB
+func (m Money) Minor() int64 {
+	return m.Value.Shift(MinorUnits(m.Currency)).Round(0).IntPart()
+}
+
+// Add returns m + other, or ErrCurrencyMismatch if currencies differ
+func (m Money) Add(other Money) (Money, error) {
+	if err := m.sameCurrency(other); err != nil {
+		return Money{}, err
+	}
+	return NewMoney(m.Value.Add(other.Value), m.Currency), nil
+}
+
+// Sub returns m - other, or ErrCurrencyMismatch if currencies differ
+func (m Money) Sub(other Money) (Money, error) {
+	if err := m.sameCurrency(other); err != nil {
+		return Money{}, err
+	}
+	return NewMoney(m.Value.Sub(other.Value), m.Currency), nil
+}
+
+// Cmp compares m and other and returns -1, 0 or +1, or ErrCurrencyMismatch if currencies differ
+func (m Money) Cmp(other Money) (int, error) {
+	if err := m.sameCurrency(other); err != nil {
+		return 0, err
+	}
+	return m.Round().Value.Cmp(other.Round().Value), nil
+}
+
+// Equal reports whether m and other have the same currency and value
+func (m Money) Equal(other Money) bool {
+	cmp, err := m.Cmp(other)
+	return err == nil && cmp == 0
+}
+
+// IsZero reports whether m is zero
+func (m Money) IsZero() bool {
+	return m.Round().Value.IsZero()
+}
+
+// IsPositive reports whether m is greater than zero
+func (m Money) IsPositive() bool {
+	return m.Round().Value.IsPositive()
+}
+
+// IsNegative reports whether m is less than zero
+func (m Money) IsNegative() bool {
+	return m.Round().Value.IsNegative()
+}
+
+// Allocate splits m into parts proportional to ratios without losing minor units,
+// the remainder is given to the first parts one minor unit at a time
+//
+// Example: payment.NewMoney(decimal.NewFromInt(100), "RUB").Allocate(1, 1, 1) is 33.34, 33.33 and 33.33 RUB
+func (m Money) Allocate(ratios ...int) ([]Money, error) {
+	total := 0
+	for _, ratio := range ratios {
+		if ratio < 0 {
+			return nil, errors.New("allocation ratios must not be negative")
+		}
+		total += ratio
+	}
+	if total == 0 {
+		return nil, errors.New("allocation ratios must have positive sum")
+	}
+
+	units := m.Minor()
+	parts := make([]int64, len(ratios))
+	remainder := units
+	for i, ratio := range ratios {
+		parts[i] = units * int64(ratio) / int64(total)
+		remainder -= parts[i]
+	}
+	step := int64(1)
+	if remainder < 0 {
+		step = -1
+	}
+	for i := 0; remainder != 0; i = (i + 1) % len(parts) {
+		if ratios[i] == 0 {
+			continue
+		}
+		parts[i] += step
+		remainder -= step
+	}
+
+	result := make([]Money, len(parts))
+	for i, part := range parts {
+		result[i] = NewMoneyFromMinor(part, m.Currency)
+	}
+	return result, nil
+}
+
+// Validate checks that m can be used as amount of a request: value is positive, fits the currency's
+// minor units and currency is a three letter code. The error is ValidationErrors with value and currency parameters
+func (m Money) Validate() error {
+	var errs ValidationErrors
+	if !m.IsPositive() {
+		errs.Add("value", "amount must be positive")
+	} else if err := m.checkPrecision(); err != nil {
+		errs.AddError("value", err)
+	}
+	switch {
+	case m.Currency == "":
+		errs.Add("currency", "currency is required")
+	case len(m.Currency) != 3:
+		errs.Add("currency", fmt.Sprintf("currency %q is not a three letter code", m.Currency))
+	}
+	return errs.Err()
+}
+
+// String returns m in "100.00 RUB" format
+func (m Money) String() string {
+	if m.Currency == "" {
+		return m.value()
+	}
+	return m.value() + " " + m.Currency
+}
+
+// MarshalJSON encodes value as string with exactly the currency's minor units, like "100.00".
+// It returns ErrMoneyPrecision if value has more decimal places
+func (m Money) MarshalJSON() ([]byte, error) {
+	if err := m.checkPrecision(); err != nil {
+		return nil, err
+	}
+	type money struct {
+		Value    string `json:"value"`
+		Currency string `json:"currency,omitempty"`
+	}
+	return json.Marshal(money{Value: m.value(), Currency: m.Currency})
+}
+
+// UnmarshalJSON decodes Money, value must be a string in "100.00" format
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var money struct {
+		Value    json.RawMessage `json:"value"`
+		Currency string          `json:"currency"`
+	}
+	if err := json.Unmarshal(data, &money); err != nil {
+		return err
+	}
+	if len(money.Value) == 0 || bytes.Equal(money.Value, []byte("null")) {
+		*m = Money{Currency: money.Currency}
+		return nil
+	}
+
+	var value string
+	if err := json.Unmarshal(money.Value, &value); err != nil {
+		return fmt.Errorf("money value must be a string: %s", money.Value)
+	}
+	if !moneyValue.MatchString(value) {
+		return fmt.Errorf("invalid money value %q", value)
+	}
+	d, err := decimal.NewFromString(value)
+	if err != nil {
+		return err
+	}
+	*m = Money{Value: d, Currency: money.Currency}
+	return nil
+}
+
+func (m Money) value() string {
+	return m.Value.StringFixed(MinorUnits(m.Currency))
+}
+
+// checkPrecision returns ErrMoneyPrecision if m has more decimal places than the currency's minor units
+func (m Money) checkPrecision() error {
+	if units := MinorUnits(m.Currency); !m.Value.Equal(m.Value.Round(units)) {
+		return fmt.Errorf("%w: %s has more than %d decimal places", ErrMoneyPrecision, m.Value, units)
+	}
+	return nil
+}
+
+func (m Money) sameCurrency(other Money) error {
+	if !strings.EqualFold(m.Currency, other.Currency) {
+		return fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package payment
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/shopspring/decimal"
+	"reflect"
+	"testing"
+)
+
+func TestMoney_JSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		money Money
+		want  string
+	}{
+		{"RUB", NewMoney(decimal.NewFromInt(100), "RUB"), `{"value":"100.00","currency":"RUB"}`},
+		{"Padded", Money{Value: decimal.RequireFromString("10.5"), Currency: "RUB"}, `{"value":"10.50","currency":"RUB"}`},
+		{"JPY", NewMoney(decimal.RequireFromString("1500.4"), "JPY"), `{"value":"1500","currency":"JPY"}`},
+		{"KWD", NewMoneyFromMinor(1500, "KWD"), `{"value":"1.500","currency":"KWD"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.money)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Marshal() = %s, want %s", got, tt.want)
+			}
+
+			var decoded Money
+			if err := json.Unmarshal(got, &decoded); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if !decoded.Equal(tt.money) {
+				t.Errorf("Unmarshal() = %v, want %v", decoded, tt.money)
+			}
+		})
+	}
+
+	malformed := []string{`{"value":100,"currency":"RUB"}`, `{"value":"1e2","currency":"RUB"}`, `{"value":"","currency":"RUB"}`}
+	for _, data := range malformed {
+		var m Money
+		if err := json.Unmarshal([]byte(data), &m); err == nil {
+			t.Errorf("Unmarshal(%s) = %v, want error", data, m)
+		}
+	}
+}
+
+func TestMoney_Precision(t *testing.T) {
+	m := Money{Value: decimal.RequireFromString("100.005"), Currency: "RUB"}
+	if _, err := json.Marshal(m); !errors.Is(err, ErrMoneyPrecision) {
+		t.Errorf("Marshal() error = %v, want %v", err, ErrMoneyPrecision)
+	}
+	if err := m.Validate(); !errors.Is(err, ErrMoneyPrecision) || !reflect.DeepEqual(parameters(err), []string{"value"}) {
+		t.Errorf("Validate() error = %v, want %v", err, ErrMoneyPrecision)
+	}
+	if err := m.Round().Validate(); err != nil {
+		t.Errorf("Round().Validate() error = %v", err)
+	}
+	if err := (Money{Value: decimal.RequireFromString("1.500"), Currency: "KWD"}).Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	jpy := Money{Value: decimal.RequireFromString("1.5"), Currency: "JPY"}
+	if err := jpy.Validate(); !errors.Is(err, ErrMoneyPrecision) {
+		t.Errorf("Validate() error = %v, want %v", err, ErrMoneyPrecision)
+	}
+}
+
+func TestMoney_Arithmetic(t *testing.T) {
+	a := NewMoney(decimal.RequireFromString("100.50"), "RUB")
+	b := NewMoneyFromMinor(2025, "RUB")
+
+	sum, err := a.Add(b)
+	if err != nil || sum.String() != "120.75 RUB" {
+		t.Errorf("Add() = %v, %v", sum, err)
+	}
+	diff, err := b.Sub(a)
+	if err != nil || diff.String() != "-80.25 RUB" || !diff.IsNegative() {
+		t.Errorf("Sub() = %v, %v", diff, err)
+	}
+	if cmp, err := a.Cmp(b); err != nil || cmp != 1 {
+		t.Errorf("Cmp() = %d, %v", cmp, err)
+	}
+	if a.Minor() != 10050 {
+		t.Errorf("Minor() = %d, want 10050", a.Minor())
+	}
+
+	usd := NewMoney(decimal.NewFromInt(1), "USD")
+	if _, err := a.Add(usd); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Add() error = %v, want %v", err, ErrCurrencyMismatch)
+	}
+	if a.Equal(usd) {
+		t.Errorf("Equal() = true for different currencies")
+	}
+}
+
+func TestMoney_Allocate(t *testing.T) {
+	tests := []struct {
+		name   string
+		money  Money
+		ratios []int
+		want   []string
+	}{
+		{"Even", NewMoney(decimal.NewFromInt(100), "RUB"), []int{1, 1, 1}, []string{"33.34", "33.33", "33.33"}},
+		{"Ratios", NewMoneyFromMinor(5, "RUB"), []int{3, 7}, []string{"0.02", "0.03"}},
+		{"Zero ratio", NewMoneyFromMinor(101, "RUB"), []int{0, 1, 1}, []string{"0.00", "0.51", "0.50"}},
+		{"Negative", NewMoneyFromMinor(-100, "RUB"), []int{1, 1, 1}, []string{"-0.34", "-0.33", "-0.33"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parts, err := tt.money.Allocate(tt.ratios...)
+			if err != nil {
+				t.Fatalf("Allocate() error = %v", err)
+			}
+			total := Money{Currency: tt.money.Currency}
+			for i, part := range parts {
+				if part.value() != tt.want[i] {
+					t.Errorf("Allocate()[%d] = %v, want %s", i, part, tt.want[i])
+				}
+				total, _ = total.Add(part)
+			}
+			if !total.Equal(tt.money) {
+				t.Errorf("Allocate() total = %v, want %v", total, tt.money)
+			}
+		})
+	}
+
+	if _, err := NewMoneyFromMinor(100, "RUB").Allocate(0, 0); err == nil {
+		t.Errorf("Allocate(0, 0) error = nil")
+	}
+}
@@ -1,16 +1,20 @@
 package payment
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"github.com/hugmouse/goyookassa/consts"
+	"github.com/hugmouse/goyookassa/internal/request"
 	"github.com/shopspring/decimal"
-	"io"
 	"net/http"
+	"reflect"
 	"time"
+	"unicode/utf8"
 )
 
+// PaymentDescriptionMaxLength is maximum length of Payment description
+const PaymentDescriptionMaxLength = 128
+
 // Kassa struct is used to provide basic auth for YooKassa's endpoint
 type Kassa struct {
 	// ShopID is your, well, shop id
@@ -22,40 +26,106 @@ type Kassa struct {
 	SecretKey string
 }
 
-type FromResponse struct {
-	ID     string `json:"id"`
-	Status string `json:"status"`
-	Paid   bool   `json:"paid"`
-	Amount struct {
-		Value    string `json:"value"`
-		Currency string `json:"currency"`
-	} `json:"amount"`
-	CreatedAt   time.Time `json:"created_at"`
-	Description string    `json:"description"`
-	ExpiresAt   time.Time `json:"expires_at"`
-	Metadata    struct {
-	} `json:"metadata"`
-	PaymentMethod struct {
-		Type  string `json:"type"`
-		ID    string `json:"id"`
-		Saved bool   `json:"saved"`
-		Card  struct {
-			First6        string `json:"first6"`
-			Last4         string `json:"last4"`
-			ExpiryMonth   string `json:"expiry_month"`
-			ExpiryYear    string `json:"expiry_year"`
-			CardType      string `json:"card_type"`
-			IssuerCountry string `json:"issuer_country"`
-			IssuerName    string `json:"issuer_name"`
-		} `json:"card"`
-		Title string `json:"title"`
-	} `json:"payment_method"`
-	Recipient struct {
-		AccountID string `json:"account_id"`
-		GatewayID string `json:"gateway_id"`
-	} `json:"recipient"`
+// PaymentObject is payment object returned by YooKassa's endpoint.
+// It is used by every payment call: create, get, list, capture and cancel
+//
+// Learn more: https://yookassa.ru/en/developers/api#payment_object
+type PaymentObject struct {
+	// ID is payment's id in YooKassa
+	ID string `json:"id"`
+	// Status is payment status: pending, waiting_for_capture, succeeded or canceled
+	Status Status `json:"status"`
+	// Amount is payment amount
+	Amount Amount `json:"amount"`
+	// IncomeAmount is payment amount that the store will receive, i.e. Amount minus YooMoney's commission
+	IncomeAmount *Amount `json:"income_amount,omitempty"`
+	// Description is payment description
+	Description string `json:"description,omitempty"`
+	// Recipient is payment recipient
+	Recipient Recipient `json:"recipient"`
+	// PaymentMethod is payment method used for this payment
+	PaymentMethod *Method `json:"payment_method,omitempty"`
+	// CapturedAt is time of payment capture
+	CapturedAt *time.Time `json:"captured_at,omitempty"`
+	// CreatedAt is time of payment creation
+	CreatedAt time.Time `json:"created_at"`
+	// ExpiresAt is the period during which you can cancel or capture the payment for free
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	// Confirmation is selected payment confirmation scenario
+	Confirmation *Confirmation `json:"confirmation,omitempty"`
+	// Test is the flag of the test operation
+	Test bool `json:"test"`
+	// RefundedAmount is amount that was refunded to the user
+	RefundedAmount *Amount `json:"refunded_amount,omitempty"`
+	// Paid is the flag of payment status
+	Paid bool `json:"paid"`
+	// Refundable is availability of the option to make a refund via API
 	Refundable bool `json:"refundable"`
-	Test       bool `json:"test"`
+	// ReceiptRegistration is status of receipt delivery: pending, succeeded or canceled
+	ReceiptRegistration string `json:"receipt_registration,omitempty"`
+	// Metadata is any additional data you might require for processing payments
+	Metadata Metadata `json:"metadata,omitempty"`
+	// CancellationDetails is commentary to the canceled status
+	CancellationDetails *CancellationDetails `json:"cancellation_details,omitempty"`
+	// AuthorizationDetails is payment authorization details
+	AuthorizationDetails *AuthorizationDetails `json:"authorization_details,omitempty"`
+	// Transfers is data on distribution of funds between stores
+	Transfers []Transfer `json:"transfers,omitempty"`
+	// Deal is the deal within which the payment is being carried out
+	Deal *Deal `json:"deal,omitempty"`
+	// MerchantCustomerID is id of the user in your system, like email address or phone number
+	MerchantCustomerID string `json:"merchant_customer_id,omitempty"`
+	// IdempotenceKey is idempotence key the request was sent with, it is empty for fetched objects
+	IdempotenceKey string `json:"-"`
+}
+
+// FromResponse is payment returned by GetPayment
+//
+// Deprecated: use PaymentObject
+type FromResponse = PaymentObject
+
+// YooKassaResponse is default YooKassa endpoint response to payment creation request
+//
+// Deprecated: use PaymentObject
+type YooKassaResponse = PaymentObject
+
+// Items is payment in List
+//
+// Deprecated: use PaymentObject
+type Items = PaymentObject
+
+// AuthorizationDetails is payment authorization details
+type AuthorizationDetails struct {
+	// RRN is Retrieval Reference Number, a unique identifier of a transaction in the issuer's system
+	RRN string `json:"rrn,omitempty"`
+	// AuthCode is bank card's authorization code
+	AuthCode string `json:"auth_code,omitempty"`
+	// ThreeDSecure is user authentication details by 3-D Secure
+	ThreeDSecure *ThreeDSecure `json:"three_d_secure,omitempty"`
+}
+
+// ThreeDSecure is user authentication details by 3-D Secure
+type ThreeDSecure struct {
+	// Applied is true if payment was processed with 3-D Secure
+	Applied bool `json:"applied"`
+}
+
+// Deal is the deal within which the payment is being carried out
+//
+// Learn more at: https://yookassa.ru/en/developers/solutions-for-platforms/safe-deal/basics
+type Deal struct {
+	// ID is id of the deal
+	ID string `json:"id"`
+	// Settlements is information about money distribution
+	Settlements []Settlement `json:"settlements"`
+}
+
+// Settlement is information about money distribution in Deal
+type Settlement struct {
+	// Type is transaction type, like payout
+	Type string `json:"type"`
+	// Amount is transaction amount
+	Amount Amount `json:"amount"`
 }
 
 type Payment struct {
@@ -70,8 +140,18 @@ type Payment struct {
 	// Such behavior helps prevent unwanted repetition of transactions:
 	// for example, if during the payment process the Internet connection was interrupted due to network problems,
 	// you’ll be able to safely repeat the request for an unlimited number of times.
+	//
+	// If it is empty, every call of Do generates a new UUID v4, or derives the key from OperationID.
+	// The key is returned as PaymentObject.IdempotenceKey, or by IdempotenceKeyOf if the request failed,
+	// so you can persist it and repeat the request. The key must not exceed 64 characters.
 	IdempotenceKey string `json:"-"`
 
+	// OperationID identifies the operation, see IdempotencyStore
+	OperationID string `json:"-"`
+
+	// Client is used to send the payment. If nil, a new Client is created for Kassa
+	Client *Client `json:"-"`
+
 	Amount Amount `json:"amount"`
 
 	// Capture that was set to true means you will receive the money immediately after the payment.
@@ -86,15 +166,16 @@ type Payment struct {
 	// More about confirmation scenarios: https://yookassa.ru/en/developers/payments/payment-process#user-confirmation
 	Confirmation *Confirmation `json:"confirmation,omitempty"`
 
-	// PaymentMethodData is used in recurrent payments
+	// PaymentMethodData is payment method data, like BankCardData or SberbankData, or RawMethodData for other methods.
+	// It is also used in recurrent payments
 	//
 	// More info: https://yookassa.ru/en/developers/payments/recurring-payments#save
-	PaymentMethodData *MethodData `json:"payment_method_data,omitempty"`
+	PaymentMethodData MethodData `json:"payment_method_data,omitempty"`
 
 	// Description is used if you want to add a payment description that’ll be displayed in the Merchant Profile to you,
 	// and during the payment to the user
 	//
-	// Also description must not exceed 128 characters
+	// Also description must not exceed PaymentDescriptionMaxLength characters
 	Description string `json:"description,omitempty"`
 
 	// SavePaymentMethod is used for recurrent payments
@@ -102,6 +183,18 @@ type Payment struct {
 	// Learn more at: https://yookassa.ru/en/developers/payments/recurring-payments
 	SavePaymentMethod bool `json:"save_payment_method,omitempty"`
 
+	// Receipt is data for creating a receipt in accordance with 54-FZ.
+	// Items total must match the Amount
+	//
+	// Learn more at: https://yookassa.ru/en/developers/payment-acceptance/receipts/basics
+	Receipt *Receipt `json:"receipt,omitempty"`
+
+	// Metadata is any additional data you might require for processing payments, like your order id.
+	// It is returned back in responses and notifications
+	//
+	// Learn more at: https://yookassa.ru/en/developers/using-api/basics#metadata
+	Metadata Metadata `json:"metadata,omitempty"`
+
 	// PaymentMethodID is used for recurrent payments
 	//
 	// Recurring payments are only enabled by default in the demo store.
@@ -111,37 +204,6 @@ type Payment struct {
 	PaymentMethodID string `json:"payment_method_id,omitempty"`
 }
 
-type MethodData struct {
-	Type string `json:"type"`
-}
-
-// YooKassaResponse is default YooKassa endpoint response to payment creation request
-type YooKassaResponse struct {
-	ID           string                   `json:"id"`
-	Status       string                   `json:"status"`
-	Paid         bool                     `json:"paid"`
-	Amount       AmountFromResponse       `json:"amount"`
-	Confirmation ConfirmationFromResponse `json:"confirmation"`
-	CreatedAt    time.Time                `json:"created_at"`
-	Description  string                   `json:"description"`
-	Recipient    Recipient                `json:"recipient"`
-	Refundable   bool                     `json:"refundable"`
-	Test         bool                     `json:"test"`
-}
-
-// YooKassaErrorResponse is used for handling error responses from YooKassa's endpoint
-type YooKassaErrorResponse struct {
-	Type        string `json:"type"`
-	ID          string `json:"id"`
-	Code        string `json:"code"`
-	Description string `json:"description"`
-	Parameter   string `json:"parameter"`
-}
-
-func (y *YooKassaErrorResponse) Error() string {
-	return fmt.Sprintf("api returned error: %s (in parameter %s)", y.Description, y.Parameter)
-}
-
 // Recipient Payment.
 //
 // Required for separating payment flows within one account or making payments to other accounts.
@@ -150,22 +212,15 @@ type Recipient struct {
 	GatewayID string `json:"gateway_id"`
 }
 
-// Amount (payment amount)
-//
-// Sometimes YooMoney's partners charge additional commission from the users that is not included in this amount.
-type Amount struct {
-	// Value is how much money you want to get from someone
-	Value decimal.Decimal `json:"value"`
-	// Currency is three letter currency code (ex: RUB)
-	Currency string `json:"currency,omitempty"`
-}
-
 // Confirmation information required to initiate the selected payment confirmation scenario by the user.
 //
+// The same type is used in requests and responses: ConfirmationURL, ConfirmationToken
+// and ConfirmationData are set by YooKassa depending on the scenario.
+//
 // More about confirmation scenarios: https://yookassa.ru/en/developers/payments/payment-process#user-confirmation
 type Confirmation struct {
 	// Type Confirmation scenario code
-	Type string `json:"type"`
+	Type ConfirmationType `json:"type"`
 	// Enforce a request for making a payment with authentication by 3-D Secure.
 	//
 	// It works if you accept bank card payments without user confirmation by default.
@@ -173,98 +228,121 @@ type Confirmation struct {
 	// If you would like to accept payments without additional confirmation by the user, contact your YooMoney manager.
 	//
 	// Works only with ConfirmationType == Redirect
-	Enforce bool `json:"enforce"`
+	Enforce bool `json:"enforce,omitempty"`
+	// Locale is language of the interface, emails, and text messages that will be displayed or sent to the user.
+	// Possible values: ru_RU, en_US
+	Locale string `json:"locale,omitempty"`
 	// ReturnURL is the URL that the user will return to after confirming or canceling the payment on the webpage
-	ReturnURL string `json:"return_url"`
-}
-
-type AmountFromResponse struct {
-	Value    string `json:"value"`
-	Currency string `json:"currency"`
-}
-
-type ConfirmationFromResponse struct {
-	Type            string `json:"type"`
-	ConfirmationURL string `json:"confirmation_url"`
-}
+	//
+	// Required for Redirect and MobileApplication, optional for QR
+	ReturnURL string `json:"return_url,omitempty"`
+	// ConfirmationURL is the URL that the user must be redirected to, set in responses for Redirect
+	// and MobileApplication
+	ConfirmationURL string `json:"confirmation_url,omitempty"`
+	// ConfirmationToken is the token for initializing the YooMoney Checkout Widget, set in responses for Embedded
+	ConfirmationToken string `json:"confirmation_token,omitempty"`
+	// ConfirmationData is data for generating the QR code, set in responses for QR
+	ConfirmationData string `json:"confirmation_data,omitempty"`
+}
+
+// ConfirmationFromResponse is confirmation returned in PaymentObject
+//
+// Deprecated: use Confirmation
+type ConfirmationFromResponse = Confirmation
 
 // ConfirmationType is one of the Confirmation scenarios
 //
 // More about confirmation scenarios: https://yookassa.ru/en/developers/payments/payment-process#confirmation-scenarios
-type ConfirmationType int
+type ConfirmationType string
 
 const (
 	// Embedded confirmation scenario: actions required for payment confirmation will depend on the payment method
 	// selected by the user in the YooMoney Checkout Widget.
 	// YooMoney will receive the confirmation from the user: all you need to do is embed the widget to your page.
-	Embedded ConfirmationType = iota
+	Embedded ConfirmationType = "embedded"
 
 	// External confirmation scenario:
 	// to continue, the user takes action in an external system (for example, responds to a text message).
 	// All you need to do is let them know how to proceed.
-	External
+	External ConfirmationType = "external"
 
 	// The MobileApplication confirmation scenario: to confirm a payment, the user needs to complete an action
 	// in a mobile app (for example, in an online banking app).
-	// You need to redirect the user to the ConfirmationURL (ConfirmationFromResponse) received in the payment.
+	// You need to redirect the user to the ConfirmationURL (Confirmation) received in the payment.
 	// After the payment is made successfully (or if something goes wrong), YooMoney will redirect the user back
 	// to the return_url that you send in your request for creating the payment.
 	// This payment confirmation scenario only works on mobile devices (via mobile app or mobile web version).
-	MobileApplication
+	MobileApplication ConfirmationType = "mobile_application"
 
 	// QR confirmation scenario: to confirm the payment, the user scans a QR code.
 	// You will need to generate the QR code using any available tools and display it on the payment page.
-	QR
+	QR ConfirmationType = "qr"
 
 	// Redirect confirmation scenario: the user takes action on the YooMoney’s page or its partner’s page
 	// (for example, enters bank card details or completes identification process via 3-D Secure).
-	// You must redirect the user to ConfirmationURL (ConfirmationFromResponse) received in the payment.
+	// You must redirect the user to ConfirmationURL (Confirmation) received in the payment.
 	// If the payment is successful (or if something goes wrong),
 	// YooMoney will return the user to return_url that you’ll send in the payment creation request.
-	Redirect
+	Redirect ConfirmationType = "redirect"
 )
 
 func (c ConfirmationType) String() string {
-	return [...]string{"embedded", "external", "mobile_application", "qr", "redirect"}[c]
+	return string(c)
+}
+
+// IsValid reports whether c is one of the known confirmation scenarios
+func (c ConfirmationType) IsValid() bool {
+	switch c {
+	case Embedded, External, MobileApplication, QR, Redirect:
+		return true
+	}
+	return false
+}
+
+// Validate checks that confirmation has a known type and that ReturnURL is set
+// for Redirect and MobileApplication scenarios, the error is ValidationErrors
+func (c *Confirmation) Validate() error {
+	var errs ValidationErrors
+	if !c.Type.IsValid() {
+		errs.Add("type", fmt.Sprintf("unknown confirmation type %q", c.Type))
+	}
+	if (c.Type == Redirect || c.Type == MobileApplication) && c.ReturnURL == "" {
+		errs.Add("return_url", fmt.Sprintf("return_url is required for %s confirmation", c.Type))
+	}
+	if c.Enforce && c.Type != Redirect {
+		errs.Add("enforce", fmt.Sprintf("enforce works only with %s confirmation, got %s", Redirect, c.Type))
+	}
+	return errs.Err()
+}
+
+// MarshalJSON encodes only the fields that belong to the confirmation scenario
+func (c Confirmation) MarshalJSON() ([]byte, error) {
+	type confirmation Confirmation
+	out := confirmation{Type: c.Type, Locale: c.Locale}
+	switch c.Type {
+	case Embedded:
+		out.ConfirmationToken = c.ConfirmationToken
+	case MobileApplication:
+		out.ReturnURL = c.ReturnURL
+		out.ConfirmationURL = c.ConfirmationURL
+	case QR:
+		out.ReturnURL = c.ReturnURL
+		out.ConfirmationData = c.ConfirmationData
+	case Redirect:
+		out.Enforce = c.Enforce
+		out.ReturnURL = c.ReturnURL
+		out.ConfirmationURL = c.ConfirmationURL
+	case External:
+	default:
+		out = confirmation(c)
+	}
+	return json.Marshal(out)
 }
 
 type List struct {
-	Type       string  `json:"type"`
-	Items      []Items `json:"items"`
-	NextCursor string  `json:"next_cursor"`
-}
-type Metadata struct {
-}
-type Card struct {
-	First6        string `json:"first6"`
-	Last4         string `json:"last4"`
-	ExpiryMonth   string `json:"expiry_month"`
-	ExpiryYear    string `json:"expiry_year"`
-	CardType      string `json:"card_type"`
-	IssuerCountry string `json:"issuer_country"`
-	IssuerName    string `json:"issuer_name"`
-}
-type Method struct {
-	Type  string `json:"type"`
-	ID    string `json:"id"`
-	Saved bool   `json:"saved"`
-	Card  Card   `json:"card"`
-	Title string `json:"title"`
-}
-
-type Items struct {
-	ID            string    `json:"id"`
-	Status        string    `json:"status"`
-	Paid          bool      `json:"paid"`
-	Amount        Amount    `json:"amount"`
-	CreatedAt     time.Time `json:"created_at"`
-	Description   string    `json:"description"`
-	ExpiresAt     time.Time `json:"expires_at"`
-	Metadata      Metadata  `json:"metadata"`
-	PaymentMethod Method    `json:"payment_method"`
-	Recipient     Recipient `json:"recipient"`
-	Refundable    bool      `json:"refundable"`
-	Test          bool      `json:"test"`
+	Type       string          `json:"type"`
+	Items      []PaymentObject `json:"items"`
+	NextCursor string          `json:"next_cursor"`
 }
 
 // NewKassa creates and initializes a new Kassa (YooKassa shop id and shop secret key)
@@ -299,12 +377,25 @@ func (p *Payment) SetKassa(kassa *Kassa) *Payment {
 	return p
 }
 
-// SetIdempotenceKey sets payment's idempotence key
+// SetClient sets client that is used to send the payment
+func (p *Payment) SetClient(client *Client) *Payment {
+	p.Client = client
+	return p
+}
+
+// SetIdempotenceKey sets payment's idempotence key (64 character max).
+// If it is not set, a UUID v4 is generated
 func (p *Payment) SetIdempotenceKey(key string) *Payment {
 	p.IdempotenceKey = key
 	return p
 }
 
+// SetOperationID sets id of the operation, see IdempotencyStore
+func (p *Payment) SetOperationID(id string) *Payment {
+	p.OperationID = id
+	return p
+}
+
 // SetAmount sets payment's amount of money and money's type
 //
 // Example: payment.NewPayment().SetAmount(decimal.NewFromInt(500), "RUB")
@@ -334,6 +425,20 @@ func (p *Payment) SetDescription(desc string) *Payment {
 	return p
 }
 
+// SetReceipt sets payment's receipt
+func (p *Payment) SetReceipt(receipt Receipt) *Payment {
+	p.Receipt = &receipt
+	return p
+}
+
+// SetMetadata sets payment's metadata (16 keys max, key 32 characters max, value 512 characters max)
+//
+// Example: payment.NewPayment().SetMetadata(payment.Metadata{"order_id": "37"})
+func (p *Payment) SetMetadata(metadata Metadata) *Payment {
+	p.Metadata = metadata
+	return p
+}
+
 // SetSavePaymentMethod saves the payment method, used in recurrent payments
 func (p *Payment) SetSavePaymentMethod(save bool) *Payment {
 	p.SavePaymentMethod = save
@@ -349,98 +454,84 @@ func (p *Payment) SetPaymentMethodID(id string) *Payment {
 
 // SetPaymentMethodData sets payment method. Like bank card
 //
+// Example: payment.NewPayment().SetPaymentMethodData(payment.SberbankData{Phone: "79000000000"})
+//
 // More info: https://yookassa.ru/en/developers/payments/recurring-payments#save
 func (p *Payment) SetPaymentMethodData(md MethodData) *Payment {
-	p.PaymentMethodData = &md
+	p.PaymentMethodData = md
 	return p
 }
 
 // Do sends an HTTP request to YooKassa payment endpoint
-func (p *Payment) Do() (*YooKassaResponse, error) {
-	payloadBytes, err := json.Marshal(p)
-	if err != nil {
-		return nil, err
-	}
-	body := bytes.NewReader(payloadBytes)
+func (p *Payment) Do() (*PaymentObject, error) {
+	return p.DoContext(context.Background())
+}
 
-	req, err := http.NewRequest("POST", consts.Endpoint+"payments", body)
-	if err != nil {
-		return nil, err
+// Validate checks the payment before it is sent: amount, description, confirmation, payment method,
+// metadata and receipt. The error is ValidationErrors with every invalid parameter
+func (p *Payment) Validate() error {
+	var errs ValidationErrors
+	errs.Merge("amount", p.Amount.Validate())
+	if utf8.RuneCountInString(p.Description) > PaymentDescriptionMaxLength {
+		errs.Add("description", fmt.Sprintf("description is longer than %d characters", PaymentDescriptionMaxLength))
 	}
-	req.SetBasicAuth(p.Kassa.ShopID, p.Kassa.SecretKey)
-	req.Header.Set(consts.IdempotentHeader, p.IdempotenceKey)
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return nil, err
+	if p.Confirmation != nil {
+		errs.Merge("confirmation", p.Confirmation.Validate())
 	}
-	defer resp.Body.Close()
-
-	stuff, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
+	if p.PaymentMethodData != nil {
+		if v := reflect.ValueOf(p.PaymentMethodData); v.Kind() == reflect.Ptr && v.IsNil() {
+			errs.Add("payment_method_data", "payment_method_data is nil pointer")
+		} else if p.PaymentMethodData.MethodType() == "" {
+			errs.Add("payment_method_data.type", "type is required")
+		}
+		if p.PaymentMethodID != "" {
+			errs.Add("payment_method_data", "payment_method_data can't be set together with payment_method_id")
+		}
 	}
-
-	yooKassaError := &YooKassaErrorResponse{}
-	err = json.Unmarshal(stuff, yooKassaError)
-	if err != nil {
-		return nil, err
+	errs.Merge("metadata", p.Metadata.Validate())
+	if p.Receipt != nil {
+		errs.Merge("receipt", p.Receipt.Validate(&p.Amount))
 	}
-
-	if yooKassaError.Type == "error" {
-		return nil, yooKassaError
+	return errs.Err()
+}
+
+// DoContext sends an HTTP request to YooKassa payment endpoint using the provided context.
+// The payment is validated first, unless Client.SkipValidation is set
+func (p *Payment) DoContext(ctx context.Context) (*PaymentObject, error) {
+	ctx, key := request.Operation(ctx, http.MethodPost, "payments", p.OperationID, p.IdempotenceKey)
+	client := clientFor(p.Client, p.Kassa)
+	if !client.SkipValidation {
+		if err := p.Validate(); err != nil {
+			return nil, err
+		}
 	}
 
-	respKassa := &YooKassaResponse{}
-	err = json.Unmarshal(stuff, respKassa)
+	respKassa := &PaymentObject{}
+	key, err := client.SendWithKey(ctx, http.MethodPost, "payments", key, p, respKassa)
 	if err != nil {
 		return nil, err
 	}
+	respKassa.IdempotenceKey = key
 
 	return respKassa, nil
 }
 
-func (c *Kassa) ListPayments() *List {
-	req, err := http.NewRequest("GET", consts.Endpoint+"payments", nil)
-	if err != nil {
-		return nil
-	}
-	req.SetBasicAuth(c.ShopID, c.SecretKey)
-
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return nil
-	}
-	defer resp.Body.Close()
-
-	listFromJSON := new(List)
-	err = json.NewDecoder(resp.Body).Decode(&listFromJSON)
-	if err != nil {
-		return nil
-	}
-
-	return listFromJSON
+// ListPayments returns a page of payments that match the filter. The filter may be nil
+func (c *Kassa) ListPayments(filter *ListFilter) (*List, error) {
+	return NewClient(c).ListPayments(filter)
 }
 
-func (c *Kassa) GetPayment(id string) *FromResponse {
-	req, err := http.NewRequest("GET", consts.Endpoint+"payments/"+id, nil)
-	if err != nil {
-		return nil
-	}
-	req.SetBasicAuth(c.ShopID, c.SecretKey)
-
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return nil
-	}
-	defer resp.Body.Close()
+// ListPaymentsContext returns a page of payments that match the filter using the provided context
+func (c *Kassa) ListPaymentsContext(ctx context.Context, filter *ListFilter) (*List, error) {
+	return NewClient(c).ListPaymentsContext(ctx, filter)
+}
 
-	payment := new(FromResponse)
-	err = json.NewDecoder(resp.Body).Decode(&payment)
-	if err != nil {
-		return nil
-	}
+// GetPayment returns payment by its id
+func (c *Kassa) GetPayment(id string) (*PaymentObject, error) {
+	return NewClient(c).GetPayment(id)
+}
 
-	return payment
+// GetPaymentContext returns payment by its id using the provided context
+func (c *Kassa) GetPaymentContext(ctx context.Context, id string) (*PaymentObject, error) {
+	return NewClient(c).GetPaymentContext(ctx, id)
 }
@@ -1,9 +1,11 @@
 package payment
 
 import (
+	"github.com/hugmouse/goyookassa/consts"
 	"github.com/shopspring/decimal"
 	"math/rand"
-	"os"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"strings"
 	"testing"
@@ -56,16 +58,6 @@ func TestNewKassa(t *testing.T) {
 }
 
 func TestNewPayment(t *testing.T) {
-	ShopSecretKey := os.Getenv("SHOP_SECRET_KEY")
-	if ShopSecretKey == "" {
-		t.Errorf("SHOP_SECRET_KEY environment variable was not set. SHOP_SECRET_KEY = %v", ShopSecretKey)
-	}
-
-	ShopID := os.Getenv("SHOP_ID")
-	if ShopSecretKey == "" {
-		t.Errorf("SHOP_ID environment variable was not set. SHOP_SECRET_KEY = %v", ShopID)
-	}
-
 	// Pseudo-random for testing purposes
 	rand.Seed(time.Now().UnixNano())
 	chars := []rune("ABCDEFGHIJKLMNOPQRSTUVWXYZ" + "0123456789")
@@ -75,13 +67,12 @@ func TestNewPayment(t *testing.T) {
 		b.WriteRune(chars[rand.Intn(len(chars))])
 	}
 
-	Kassa := NewKassa().SetSecretKey(ShopSecretKey).SetShopID(ShopID)
+	Kassa := NewKassa().SetSecretKey("key").SetShopID("1")
 
 	tests := []struct {
 		name string
 		want *Payment
 	}{
-		// TODO: Add test cases.
 		{name: "Default", want: NewPayment().
 			SetKassa(Kassa).
 			SetIdempotenceKey(b.String()).
@@ -95,7 +86,17 @@ func TestNewPayment(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := NewPayment(); !reflect.DeepEqual(got, tt.want) {
+			got := NewPayment()
+			got.Kassa = Kassa
+			got.IdempotenceKey = b.String()
+			got.Amount = Amount{Value: decimal.NewFromInt(666), Currency: "RUB"}
+			got.Capture = true
+			got.Confirmation = &Confirmation{
+				Type:      "redirect",
+				ReturnURL: "https://www.merchant-website.com/return_url",
+			}
+			got.Description = "Default GoYooKassa test"
+			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("NewPayment() = %v, want %v", got, tt.want)
 			}
 		})
@@ -103,15 +104,8 @@ func TestNewPayment(t *testing.T) {
 }
 
 func TestPayment_Do(t *testing.T) {
-	ShopSecretKey := os.Getenv("SHOP_SECRET_KEY")
-	if ShopSecretKey == "" {
-		t.Errorf("SHOP_SECRET_KEY environment variable was not set. SHOP_SECRET_KEY = %v", ShopSecretKey)
-	}
-
-	ShopID := os.Getenv("SHOP_ID")
-	if ShopSecretKey == "" {
-		t.Errorf("SHOP_ID environment variable was not set. SHOP_SECRET_KEY = %v", ShopID)
-	}
+	ShopSecretKey := "key"
+	ShopID := "1"
 
 	// Pseudo-random for testing purposes
 	rand.Seed(time.Now().UnixNano())
@@ -122,6 +116,25 @@ func TestPayment_Do(t *testing.T) {
 		b.WriteRune(chars[rand.Intn(len(chars))])
 	}
 
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, key, ok := r.BasicAuth()
+		if !ok || id != ShopID || key != ShopSecretKey {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"type":"error","id":"1","code":"invalid_credentials",` +
+				`"description":"Authentication by given credentials failed"}`))
+			return
+		}
+		if r.Header.Get(consts.IdempotentHeader) != b.String() {
+			t.Errorf("Idempotence-Key = %q, want %q", r.Header.Get(consts.IdempotentHeader), b.String())
+		}
+		_, _ = w.Write([]byte(`{"id":"0","status":"pending","paid":false,` +
+			`"amount":{"value":"666.00","currency":"RUB"},` +
+			`"confirmation":{"type":"redirect","confirmation_url":"https://yoomoney.ru/checkout"},` +
+			`"created_at":"2021-08-13T14:13:46.45Z","description":"Default test in GoYooKassa package",` +
+			`"recipient":{"account_id":"666999","gateway_id":"1869069"},"refundable":false,"test":true}`))
+	}))
+	defer server.Close()
+
 	OurKassa := NewKassa().SetSecretKey(ShopSecretKey).SetShopID(ShopID)
 	type fields struct {
 		Kassa          *Kassa
@@ -134,18 +147,10 @@ func TestPayment_Do(t *testing.T) {
 	tests := []struct {
 		name    string
 		fields  fields
-		want    *YooKassaResponse
+		want    *PaymentObject
 		wantErr bool
 	}{
-		// TODO: Add test cases.
-		{name: "Default", fields: struct {
-			Kassa          *Kassa
-			IdempotenceKey string
-			Amount         Amount
-			Capture        bool
-			Confirmation   Confirmation
-			Description    string
-		}{
+		{name: "Default", fields: fields{
 			Kassa:          OurKassa,
 			IdempotenceKey: b.String(),
 			Amount: Amount{
@@ -156,15 +161,27 @@ func TestPayment_Do(t *testing.T) {
 				ReturnURL: "https://www.merchant-website.com/return_url",
 			}, Description: "Default test in GoYooKassa package"},
 			want: nil, wantErr: false},
+		{name: "Invalid credentials", fields: fields{
+			Kassa:          NewKassa().SetShopID(ShopID).SetSecretKey("wrong"),
+			IdempotenceKey: b.String(),
+			Amount: Amount{
+				Value:    decimal.NewFromInt(666),
+				Currency: "RUB",
+			}, Confirmation: Confirmation{
+				Type:      Redirect,
+				ReturnURL: "https://www.merchant-website.com/return_url",
+			}, Description: "Default test in GoYooKassa package"},
+			want: nil, wantErr: true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			p := &Payment{
 				Kassa:          tt.fields.Kassa,
+				Client:         NewClient(tt.fields.Kassa).SetBaseURL(server.URL),
 				IdempotenceKey: tt.fields.IdempotenceKey,
 				Amount:         tt.fields.Amount,
 				Capture:        tt.fields.Capture,
-				Confirmation:   tt.fields.Confirmation,
+				Confirmation:   &tt.fields.Confirmation,
 				Description:    tt.fields.Description,
 			}
 			_, err := p.Do()
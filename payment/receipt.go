@@ -0,0 +1,211 @@
+package payment
+
+import (
+	"errors"
+	"fmt"
+	"github.com/shopspring/decimal"
+	"unicode/utf8"
+)
+
+// PaymentSubject is payment subject attribute of ReceiptItem
+//
+// Learn more at: https://yookassa.ru/en/developers/payment-acceptance/receipts/54fz/parameters-values#payment-subject
+type PaymentSubject string
+
+const (
+	PaymentSubjectCommodity            PaymentSubject = "commodity"
+	PaymentSubjectExcise               PaymentSubject = "excise"
+	PaymentSubjectJob                  PaymentSubject = "job"
+	PaymentSubjectService              PaymentSubject = "service"
+	PaymentSubjectGamblingBet          PaymentSubject = "gambling_bet"
+	PaymentSubjectGamblingPrize        PaymentSubject = "gambling_prize"
+	PaymentSubjectLottery              PaymentSubject = "lottery"
+	PaymentSubjectLotteryPrize         PaymentSubject = "lottery_prize"
+	PaymentSubjectIntellectualActivity PaymentSubject = "intellectual_activity"
+	PaymentSubjectPayment              PaymentSubject = "payment"
+	PaymentSubjectAgentCommission      PaymentSubject = "agent_commission"
+	PaymentSubjectPropertyRight        PaymentSubject = "property_right"
+	PaymentSubjectNonOperatingGain     PaymentSubject = "non_operating_gain"
+	PaymentSubjectInsurancePremium     PaymentSubject = "insurance_premium"
+	PaymentSubjectSalesTax             PaymentSubject = "sales_tax"
+	PaymentSubjectResortFee            PaymentSubject = "resort_fee"
+	PaymentSubjectComposite            PaymentSubject = "composite"
+	PaymentSubjectAnother              PaymentSubject = "another"
+)
+
+// PaymentMode is payment method attribute of ReceiptItem
+//
+// Learn more at: https://yookassa.ru/en/developers/payment-acceptance/receipts/54fz/parameters-values#payment-mode
+type PaymentMode string
+
+const (
+	PaymentModeFullPrepayment    PaymentMode = "full_prepayment"
+	PaymentModePartialPrepayment PaymentMode = "partial_prepayment"
+	PaymentModeAdvance           PaymentMode = "advance"
+	PaymentModeFullPayment       PaymentMode = "full_payment"
+	PaymentModePartialPayment    PaymentMode = "partial_payment"
+	PaymentModeCredit            PaymentMode = "credit"
+	PaymentModeCreditPayment     PaymentMode = "credit_payment"
+)
+
+// AgentType is type of agent selling the product or service
+type AgentType string
+
+const (
+	AgentTypeBankingPaymentAgent    AgentType = "banking_payment_agent"
+	AgentTypeBankingPaymentSubagent AgentType = "banking_payment_subagent"
+	AgentTypePaymentAgent           AgentType = "payment_agent"
+	AgentTypePaymentSubagent        AgentType = "payment_subagent"
+	AgentTypeAttorney               AgentType = "attorney"
+	AgentTypeCommissioner           AgentType = "commissioner"
+	AgentTypeAgent                  AgentType = "agent"
+)
+
+// ReceiptItemMaxDescriptionLength is maximum length of ReceiptItem description
+const ReceiptItemMaxDescriptionLength = 128
+
+// ErrReceiptTotalMismatch is returned by Receipt.Validate when items total doesn't match the amount
+var ErrReceiptTotalMismatch = errors.New("receipt items total doesn't match the amount")
+
+// Receipt is data for creating a receipt in accordance with 54-FZ
+//
+// Learn more at: https://yookassa.ru/en/developers/payment-acceptance/receipts/basics
+type Receipt struct {
+	// Customer is user details. You must specify at least the basic contact info: email or phone
+	Customer *Customer `json:"customer,omitempty"`
+
+	// Items is list of products in the order (up to 100 items)
+	Items []ReceiptItem `json:"items"`
+
+	// TaxSystemCode is store's tax system (1 to 6).
+	// Required if you use the ATOL Online solution and have several tax systems
+	TaxSystemCode int `json:"tax_system_code,omitempty"`
+}
+
+// Customer is user details that are used in Receipt
+type Customer struct {
+	// FullName is name of the organization for companies, full name for sole proprietors and individuals
+	FullName string `json:"full_name,omitempty"`
+	// INN is user's Taxpayer Identification Number
+	INN string `json:"inn,omitempty"`
+	// Email is user's email address for sending the receipt
+	Email string `json:"email,omitempty"`
+	// Phone is user's phone number for sending the receipt, specified in the ITU-T E.164 format
+	Phone string `json:"phone,omitempty"`
+}
+
+// ReceiptItem is a product in Receipt
+type ReceiptItem struct {
+	// Description is product name (maximum 128 characters)
+	Description string `json:"description"`
+	// Quantity is product quantity. Only integer values can be used for marked products
+	Quantity decimal.Decimal `json:"quantity"`
+	// Measure is unit of measurement, like piece. Required for marked products
+	Measure string `json:"measure,omitempty"`
+	// MarkQuantity is fractional quantity of the marked product
+	MarkQuantity *MarkQuantity `json:"mark_quantity,omitempty"`
+	// Amount is product price per unit
+	Amount Amount `json:"amount"`
+	// VatCode is VAT rate
+	VatCode int `json:"vat_code"`
+	// PaymentSubject is payment subject attribute
+	PaymentSubject PaymentSubject `json:"payment_subject,omitempty"`
+	// PaymentMode is payment method attribute
+	PaymentMode PaymentMode `json:"payment_mode,omitempty"`
+	// CountryOfOriginCode is country of origin code according to ISO 3166 alpha2, like RU
+	CountryOfOriginCode string `json:"country_of_origin_code,omitempty"`
+	// CustomsDeclarationNumber is customs declaration number (1 to 32 characters)
+	CustomsDeclarationNumber string `json:"customs_declaration_number,omitempty"`
+	// Excise is amount of excise tax on products including kopeks
+	Excise string `json:"excise,omitempty"`
+	// ProductCode is product code, a unique number assigned to a unit of product during marking
+	ProductCode string `json:"product_code,omitempty"`
+	// MarkCodeInfo is product code (marking code)
+	MarkCodeInfo *MarkCodeInfo `json:"mark_code_info,omitempty"`
+	// MarkMode is marking code processing mode, always "0" when set
+	MarkMode string `json:"mark_mode,omitempty"`
+	// AgentType is type of agent selling the product or service
+	AgentType AgentType `json:"agent_type,omitempty"`
+	// Supplier is information about the supplier of the product or service
+	Supplier *Supplier `json:"supplier,omitempty"`
+}
+
+// MarkQuantity is fractional quantity of the marked product
+type MarkQuantity struct {
+	Numerator   int `json:"numerator"`
+	Denominator int `json:"denominator"`
+}
+
+// MarkCodeInfo is marking code of the product. Only one of the fields must be set
+type MarkCodeInfo struct {
+	MarkCodeRaw string `json:"mark_code_raw,omitempty"`
+	Unknown     string `json:"unknown,omitempty"`
+	EAN8        string `json:"ean_8,omitempty"`
+	EAN13       string `json:"ean_13,omitempty"`
+	ITF14       string `json:"itf_14,omitempty"`
+	GS10        string `json:"gs_10,omitempty"`
+	GS1M        string `json:"gs_1m,omitempty"`
+	Short       string `json:"short,omitempty"`
+	Fur         string `json:"fur,omitempty"`
+	EGAIS20     string `json:"egais_20,omitempty"`
+	EGAIS30     string `json:"egais_30,omitempty"`
+}
+
+// Supplier is information about the supplier of the product or service
+type Supplier struct {
+	// Name is supplier's name
+	Name string `json:"name,omitempty"`
+	// Phone is supplier's phone number, specified in the ITU-T E.164 format
+	Phone string `json:"phone,omitempty"`
+	// INN is supplier's Taxpayer Identification Number
+	INN string `json:"inn,omitempty"`
+}
+
+// Total returns sum of items amounts multiplied by their quantity
+func (r *Receipt) Total() decimal.Decimal {
+	total := decimal.Zero
+	for _, item := range r.Items {
+		total = total.Add(item.Amount.Value.Mul(item.Quantity))
+	}
+	return total
+}
+
+// Validate checks receipt and, if amount is not nil, that items total matches the amount.
+// The error is ValidationErrors with parameters relative to the receipt, like items[0].vat_code
+func (r *Receipt) Validate(amount *Amount) error {
+	var errs ValidationErrors
+	if r.Customer == nil || (r.Customer.Email == "" && r.Customer.Phone == "") {
+		errs.Add("customer", "receipt customer email or phone is required")
+	}
+	if len(r.Items) == 0 {
+		errs.Add("items", "receipt must have at least one item")
+	}
+	for i, item := range r.Items {
+		path := fmt.Sprintf("items[%d]", i)
+		if item.Description == "" {
+			errs.Add(path+".description", "description is required")
+		}
+		if utf8.RuneCountInString(item.Description) > ReceiptItemMaxDescriptionLength {
+			errs.Add(path+".description",
+				fmt.Sprintf("description is longer than %d characters", ReceiptItemMaxDescriptionLength))
+		}
+		if !item.Quantity.IsPositive() {
+			errs.Add(path+".quantity", "quantity must be positive")
+		}
+		if item.Amount.Value.IsNegative() {
+			errs.Add(path+".amount.value", "amount must not be negative")
+		}
+		if item.VatCode <= 0 {
+			errs.Add(path+".vat_code", "vat_code is required")
+		}
+		if amount != nil && item.Amount.Currency != "" && amount.Currency != "" && item.Amount.Currency != amount.Currency {
+			errs.Add(path+".amount.currency", fmt.Sprintf("currency %s doesn't match %s", item.Amount.Currency, amount.Currency))
+		}
+	}
+	if amount != nil && len(r.Items) > 0 {
+		if total := NewMoney(r.Total(), amount.Currency); !total.Equal(*amount) {
+			errs.AddError("items", fmt.Errorf("%w: %s != %s", ErrReceiptTotalMismatch, total, amount.Round()))
+		}
+	}
+	return errs.Err()
+}
@@ -0,0 +1,102 @@
+package payment
+
+import (
+	"errors"
+	"github.com/shopspring/decimal"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func testReceipt() Receipt {
+	return Receipt{
+		Customer: &Customer{Email: "user@example.com"},
+		Items: []ReceiptItem{
+			{
+				Description:    "Cup",
+				Quantity:       decimal.NewFromInt(2),
+				Amount:         Amount{Value: decimal.RequireFromString("150.50"), Currency: "RUB"},
+				VatCode:        1,
+				PaymentSubject: PaymentSubjectCommodity,
+				PaymentMode:    PaymentModeFullPayment,
+			},
+			{
+				Description: "Delivery",
+				Quantity:    decimal.NewFromInt(1),
+				Amount:      Amount{Value: decimal.NewFromInt(99), Currency: "RUB"},
+				VatCode:     1,
+			},
+		},
+		TaxSystemCode: 1,
+	}
+}
+
+func TestReceipt_Validate(t *testing.T) {
+	amount := &Amount{Value: decimal.RequireFromString("400.00"), Currency: "RUB"}
+
+	noContacts := testReceipt()
+	noContacts.Customer = &Customer{FullName: "Ivanov Ivan"}
+
+	noVat := testReceipt()
+	noVat.Items[1].VatCode = 0
+
+	yen := Receipt{Customer: &Customer{Phone: "79000000000"}, Items: []ReceiptItem{{
+		Description: "Tea",
+		Quantity:    decimal.RequireFromString("1.5"),
+		Amount:      Amount{Value: decimal.NewFromInt(3), Currency: "JPY"},
+		VatCode:     1,
+	}}}
+
+	tests := []struct {
+		name    string
+		receipt Receipt
+		amount  *Amount
+		wantErr error
+	}{
+		{name: "Matching total", receipt: testReceipt(), amount: amount},
+		{name: "Without amount", receipt: testReceipt()},
+		{name: "Mismatching total", receipt: testReceipt(),
+			amount: &Amount{Value: decimal.NewFromInt(500), Currency: "RUB"}, wantErr: ErrReceiptTotalMismatch},
+		{name: "Total in minor units of currency", receipt: yen,
+			amount: &Amount{Value: decimal.NewFromInt(5), Currency: "JPY"}},
+		{name: "Without contacts", receipt: noContacts, amount: amount, wantErr: errors.New("")},
+		{name: "Without vat code", receipt: noVat, amount: amount, wantErr: errors.New("")},
+		{name: "Without items", receipt: Receipt{Customer: &Customer{Phone: "79000000000"}}, wantErr: errors.New("")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.receipt.Validate(tt.amount)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr == ErrReceiptTotalMismatch && !errors.Is(err, ErrReceiptTotalMismatch) {
+				t.Errorf("Validate() error = %v, want %v", err, ErrReceiptTotalMismatch)
+			}
+		})
+	}
+}
+
+func TestPayment_DoReceipt(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(`{"id":"some-id","status":"pending"}`))
+	}))
+	defer server.Close()
+
+	p := NewPayment().
+		SetClient(NewClient(NewKassa()).SetBaseURL(server.URL)).
+		SetAmount(decimal.NewFromInt(400), "RUB").
+		SetReceipt(testReceipt())
+	if _, err := p.Do(); err != nil {
+		t.Errorf("Do() error = %v", err)
+	}
+
+	p.SetAmount(decimal.NewFromInt(500), "RUB")
+	if _, err := p.Do(); !errors.Is(err, ErrReceiptTotalMismatch) {
+		t.Errorf("Do() error = %v, want %v", err, ErrReceiptTotalMismatch)
+	}
+	if requests != 1 {
+		t.Errorf("requests = %d, want 1", requests)
+	}
+}
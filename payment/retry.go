@@ -0,0 +1,93 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/url"
+	"time"
+)
+
+// RetryPolicy describes how failed idempotent requests are repeated by Client
+//
+// Network errors, HTTP 5xx, 429 and 202 ("request is being processed") responses are repeated
+// with the same idempotence key. If YooKassa's response has retry_after, it is used as delay,
+// otherwise the delay grows exponentially from InitialBackoff to MaxBackoff.
+// Zero InitialBackoff is taken from DefaultRetryPolicy.
+//
+// Learn more at: https://yookassa.ru/en/developers/using-api/response-handling/response-format
+type RetryPolicy struct {
+	// MaxAttempts is maximum number of attempts, including the first one
+	MaxAttempts int
+	// InitialBackoff is delay before the first retry
+	InitialBackoff time.Duration
+	// MaxBackoff is maximum delay between attempts
+	MaxBackoff time.Duration
+	// Multiplier is factor the delay is multiplied by after each attempt
+	Multiplier float64
+	// Jitter is fraction of the delay that is randomized (0 to 1), so clients don't retry at the same time
+	Jitter float64
+}
+
+// DefaultRetryPolicy returns a new RetryPolicy with sensible defaults
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     30 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+	}
+}
+
+// retryable reports whether request should be repeated after a failed attempt
+func (r *RetryPolicy) retryable(ctx context.Context, attempt int, err error) bool {
+	if r == nil || attempt >= r.MaxAttempts || ctx.Err() != nil {
+		return false
+	}
+
+	var apiErr *YooKassaErrorResponse
+	if errors.As(err, &apiErr) {
+		return apiErr.Temporary()
+	}
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.Temporary()
+	}
+
+	// *url.Error is also returned for malformed BaseURL or unsupported scheme, only its transport cause counts
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		err = urlErr.Err
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// backoff returns delay before the next attempt
+func (r *RetryPolicy) backoff(attempt int, err error) time.Duration {
+	var apiErr *YooKassaErrorResponse
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+		return apiErr.RetryAfterDuration()
+	}
+
+	initial := r.InitialBackoff
+	if initial <= 0 {
+		initial = DefaultRetryPolicy().InitialBackoff
+	}
+	multiplier := r.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	delay := float64(initial) * math.Pow(multiplier, float64(attempt-1))
+	if r.MaxBackoff > 0 && delay > float64(r.MaxBackoff) {
+		delay = float64(r.MaxBackoff)
+	}
+	if r.Jitter > 0 {
+		delay += delay * r.Jitter * (2*rand.Float64() - 1)
+	}
+	return time.Duration(delay)
+}
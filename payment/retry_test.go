@@ -0,0 +1,127 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"github.com/hugmouse/goyookassa/consts"
+	"github.com/shopspring/decimal"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func testRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond, Multiplier: 2}
+}
+
+func TestClient_SendRetry(t *testing.T) {
+	tests := []struct {
+		name         string
+		responses    []int
+		key          string
+		wantAttempts int
+		wantErr      error
+	}{
+		{name: "Internal server error", responses: []int{500, 500, 200}, key: "key", wantAttempts: 3},
+		{name: "Processing", responses: []int{202, 200}, key: "key", wantAttempts: 2},
+		{name: "Too many requests", responses: []int{429, 200}, key: "key", wantAttempts: 2},
+		{name: "Bad gateway", responses: []int{502, 200}, key: "key", wantAttempts: 2},
+		{name: "Attempts exhausted", responses: []int{500, 500, 500, 200}, key: "key", wantAttempts: 3,
+			wantErr: ErrInternalServer},
+		{name: "Invalid request is not retried", responses: []int{400, 200}, key: "key", wantAttempts: 1,
+			wantErr: ErrInvalidRequest},
+		{name: "Generated idempotence key", responses: []int{500, 200}, wantAttempts: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			key := tt.key
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if key == "" {
+					key = r.Header.Get(consts.IdempotentHeader)
+				}
+				if got := r.Header.Get(consts.IdempotentHeader); got != key || got == "" {
+					t.Errorf("Idempotence-Key = %q, want %q", got, key)
+				}
+				status := tt.responses[attempts]
+				attempts++
+				w.WriteHeader(status)
+				switch status {
+				case http.StatusOK:
+					_, _ = w.Write([]byte(`{"id":"some-id","status":"pending"}`))
+				case http.StatusAccepted:
+					_, _ = w.Write([]byte(`{"type":"processing","description":"Request accepted","retry_after":1}`))
+				case http.StatusBadRequest:
+					_, _ = w.Write([]byte(`{"type":"error","id":"1","code":"invalid_request"}`))
+				case http.StatusInternalServerError:
+					_, _ = w.Write([]byte(`{"type":"error","id":"1","code":"internal_server_error","retry_after":1}`))
+				}
+			}))
+			defer server.Close()
+
+			_, err := NewPayment().
+				SetClient(NewClient(NewKassa()).SetBaseURL(server.URL).SetRetryPolicy(testRetryPolicy())).
+				SetIdempotenceKey(tt.key).
+				SetAmount(decimal.NewFromInt(1), "RUB").
+				Do()
+			if attempts != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", attempts, tt.wantAttempts)
+			}
+			if tt.wantErr == nil && err != nil || tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("Do() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClient_SendProcessingWithoutRetries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"type":"processing","description":"Request accepted","retry_after":1800}`))
+	}))
+	defer server.Close()
+
+	_, err := NewClient(NewKassa()).SetBaseURL(server.URL).GetPayment("id")
+	if !errors.Is(err, ErrProcessing) {
+		t.Errorf("GetPayment() error = %v, want %v", err, ErrProcessing)
+	}
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	policy := &RetryPolicy{MaxAttempts: 10, InitialBackoff: time.Second, MaxBackoff: 5 * time.Second,
+		Multiplier: 2, Jitter: 0.5}
+	for attempt, want := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second} {
+		got := policy.backoff(attempt+1, errors.New("network"))
+		if got < want/2 || got > want*3/2 {
+			t.Errorf("backoff(%d) = %v, want %v ± 50%%", attempt+1, got, want)
+		}
+	}
+
+	retryAfter := &YooKassaErrorResponse{RetryAfter: 1800}
+	if got := policy.backoff(1, retryAfter); got != 1800*time.Millisecond {
+		t.Errorf("backoff() with retry_after = %v, want %v", got, 1800*time.Millisecond)
+	}
+}
+
+func TestRetryPolicy_retryable(t *testing.T) {
+	policy := testRetryPolicy()
+	ctx := context.Background()
+
+	_, err := NewClient(NewKassa()).SetBaseURL("http://[::1]:namedport").GetPayment("id")
+	if err == nil || policy.retryable(ctx, 1, err) {
+		t.Errorf("retryable(%v) = true, want false for malformed BaseURL", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+	_, err = NewClient(NewKassa()).SetBaseURL(server.URL).GetPayment("id")
+	if err == nil || !policy.retryable(ctx, 1, err) {
+		t.Errorf("retryable(%v) = false, want true for network error", err)
+	}
+
+	zero := &RetryPolicy{MaxAttempts: 2}
+	if got := zero.backoff(1, &YooKassaErrorResponse{StatusCode: http.StatusTooManyRequests}); got <= 0 {
+		t.Errorf("backoff() with zero InitialBackoff = %v, want positive delay", got)
+	}
+}
@@ -0,0 +1,89 @@
+package payment
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Status is payment status
+//
+// Learn more: https://yookassa.ru/en/developers/payment-acceptance/getting-started/payment-process#lifecycle
+type Status string
+
+const (
+	// Pending means the payment is created and waits for the user's actions
+	Pending Status = "pending"
+	// WaitingForCapture means the payment is paid and the money is authorized, waiting for Capture or Cancel
+	WaitingForCapture Status = "waiting_for_capture"
+	// Succeeded means the payment is successfully completed
+	Succeeded Status = "succeeded"
+	// Canceled means the payment is canceled, see PaymentObject.CancellationDetails for the reason
+	Canceled Status = "canceled"
+)
+
+// ErrInvalidTransition is returned by ValidateTransition when payment can't move from one status to another
+var ErrInvalidTransition = errors.New("invalid payment status transition")
+
+// transitions maps statuses to statuses the payment can move to
+var transitions = map[Status][]Status{
+	Pending:           {WaitingForCapture, Succeeded, Canceled},
+	WaitingForCapture: {Succeeded, Canceled},
+}
+
+func (s Status) String() string {
+	return string(s)
+}
+
+// IsValid reports whether s is one of the known payment statuses
+func (s Status) IsValid() bool {
+	switch s {
+	case Pending, WaitingForCapture, Succeeded, Canceled:
+		return true
+	}
+	return false
+}
+
+// IsFinal reports whether payment status can't change anymore
+func (s Status) IsFinal() bool {
+	return s == Succeeded || s == Canceled
+}
+
+// CanCapture reports whether payment with this status can be captured, see Capture
+func (s Status) CanCapture() bool {
+	return s == WaitingForCapture
+}
+
+// CanCancel reports whether payment with this status can be canceled, see Cancel
+func (s Status) CanCancel() bool {
+	return s == WaitingForCapture
+}
+
+// CanRefund reports whether payment with this status can be refunded
+func (s Status) CanRefund() bool {
+	return s == Succeeded
+}
+
+// CanTransitionTo reports whether payment can move from s to next.
+// Same status is allowed, so repeated notifications are not treated as illegal
+func (s Status) CanTransitionTo(next Status) bool {
+	if s == next {
+		return s.IsValid()
+	}
+	for _, status := range transitions[s] {
+		if status == next {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateTransition returns ErrInvalidTransition if payment can't move from one status to another,
+// for example when a stale notification arrives after the payment has already succeeded
+//
+// Example: payment.ValidateTransition(order.PaymentStatus, notified.Status)
+func ValidateTransition(from, to Status) error {
+	if !from.CanTransitionTo(to) {
+		return fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, from, to)
+	}
+	return nil
+}
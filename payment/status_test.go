@@ -0,0 +1,67 @@
+package payment
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestStatus_Helpers(t *testing.T) {
+	tests := []struct {
+		status                                Status
+		final, capture, cancel, refund, valid bool
+	}{
+		{Pending, false, false, false, false, true},
+		{WaitingForCapture, false, true, true, false, true},
+		{Succeeded, true, false, false, true, true},
+		{Canceled, true, false, false, false, true},
+		{"unknown", false, false, false, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.status.String(), func(t *testing.T) {
+			if got := tt.status.IsFinal(); got != tt.final {
+				t.Errorf("IsFinal() = %v, want %v", got, tt.final)
+			}
+			if got := tt.status.CanCapture(); got != tt.capture {
+				t.Errorf("CanCapture() = %v, want %v", got, tt.capture)
+			}
+			if got := tt.status.CanCancel(); got != tt.cancel {
+				t.Errorf("CanCancel() = %v, want %v", got, tt.cancel)
+			}
+			if got := tt.status.CanRefund(); got != tt.refund {
+				t.Errorf("CanRefund() = %v, want %v", got, tt.refund)
+			}
+			if got := tt.status.IsValid(); got != tt.valid {
+				t.Errorf("IsValid() = %v, want %v", got, tt.valid)
+			}
+		})
+	}
+}
+
+func TestValidateTransition(t *testing.T) {
+	tests := []struct {
+		from, to Status
+		wantErr  bool
+	}{
+		{Pending, WaitingForCapture, false},
+		{Pending, Succeeded, false},
+		{Pending, Canceled, false},
+		{WaitingForCapture, Succeeded, false},
+		{WaitingForCapture, Canceled, false},
+		{Succeeded, Succeeded, false},
+		{Succeeded, WaitingForCapture, true},
+		{Canceled, Succeeded, true},
+		{WaitingForCapture, Pending, true},
+		{"unknown", "unknown", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.from.String()+"->"+tt.to.String(), func(t *testing.T) {
+			err := ValidateTransition(tt.from, tt.to)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateTransition() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidTransition) {
+				t.Errorf("ValidateTransition() error = %v, want %v", err, ErrInvalidTransition)
+			}
+		})
+	}
+}
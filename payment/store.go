@@ -0,0 +1,168 @@
+package payment
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"github.com/hugmouse/goyookassa/internal/request"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// IdempotencyRecord is an operation persisted in IdempotencyStore
+//
+// The record maps well to a SQL table:
+//
+//	CREATE TABLE idempotency (
+//		operation_id    TEXT PRIMARY KEY,
+//		idempotence_key TEXT NOT NULL,
+//		response        BLOB
+//	);
+type IdempotencyRecord struct {
+	// OperationID is caller-supplied operation id scoped by the request method and path,
+	// like "POST payments order-37"
+	OperationID string `json:"operation_id"`
+	// IdempotenceKey is idempotence key the operation is sent with
+	IdempotenceKey string `json:"idempotence_key"`
+	// Response is raw response body of the operation. It is nil until the operation succeeds
+	Response json.RawMessage `json:"response,omitempty"`
+}
+
+// IdempotencyStore persists idempotence keys and responses of operations, so the operation can be safely
+// repeated after the process crashed between sending the request and storing the result
+//
+// An operation is identified by caller-supplied operation id, set with OperationID of request builders
+// (like Payment.SetOperationID) or with WithOperationID. The id is scoped by the request method and path,
+// so creating, capturing and refunding a payment are different operations even if they share the id.
+// Idempotence key of the operation is derived from the scoped id, see IdempotenceKeyFromOperation.
+//
+// The key is saved before the request is sent, and the response after it is received.
+// When the operation is repeated, the stored key is reused, or the stored response is returned
+// without sending the request.
+type IdempotencyStore interface {
+	// Get returns record of the operation, or nil if there is none
+	Get(ctx context.Context, operationID string) (*IdempotencyRecord, error)
+	// Put saves record of the operation, replacing the previous one
+	Put(ctx context.Context, record *IdempotencyRecord) error
+}
+
+// MemoryStore is IdempotencyStore that keeps records in memory. It is safe for concurrent use
+//
+// Records are lost when the process exits, so use it in tests or together with your own persistence.
+type MemoryStore struct {
+	mu      sync.Mutex
+	records map[string]IdempotencyRecord
+}
+
+// FileStore is IdempotencyStore that keeps every record in a separate JSON file in a directory
+type FileStore struct {
+	// Dir is the directory records are stored in
+	Dir string
+}
+
+// WithOperationID returns a copy of ctx that carries caller-supplied operation id, see IdempotencyStore.
+// It is used by POST requests sent with this ctx, unless request builder has its own OperationID
+func WithOperationID(ctx context.Context, operationID string) context.Context {
+	return request.WithOperationID(ctx, operationID)
+}
+
+// OperationIDFrom returns operation id carried by ctx, or empty string if there is none
+func OperationIDFrom(ctx context.Context) string {
+	return request.OperationID(ctx)
+}
+
+// IdempotenceKeyFromOperation derives idempotence key (UUID v5) of a request from operation id.
+// The same method, path and operation id always give the same key
+func IdempotenceKeyFromOperation(method, path, operationID string) string {
+	return request.IdempotenceKeyFromOperation(method, path, operationID)
+}
+
+// NewMemoryStore creates and initializes a new MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{records: make(map[string]IdempotencyRecord)}
+}
+
+// Get returns record of the operation, or nil if there is none
+func (m *MemoryStore) Get(_ context.Context, operationID string) (*IdempotencyRecord, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	record, ok := m.records[operationID]
+	if !ok {
+		return nil, nil
+	}
+	record.Response = append(json.RawMessage(nil), record.Response...)
+	return &record, nil
+}
+
+// Put saves record of the operation
+func (m *MemoryStore) Put(_ context.Context, record *IdempotencyRecord) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored := *record
+	stored.Response = append(json.RawMessage(nil), record.Response...)
+	m.records[record.OperationID] = stored
+	return nil
+}
+
+// NewFileStore creates and initializes a new FileStore. The directory is created if it doesn't exist
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &FileStore{Dir: dir}, nil
+}
+
+// Get returns record of the operation, or nil if there is none
+func (f *FileStore) Get(_ context.Context, operationID string) (*IdempotencyRecord, error) {
+	data, err := os.ReadFile(f.path(operationID))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	record := new(IdempotencyRecord)
+	if err := json.Unmarshal(data, record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// Put saves record of the operation. The file is replaced atomically and synced to disk
+func (f *FileStore) Put(_ context.Context, record *IdempotencyRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(f.Dir, ".record-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path(record.OperationID))
+}
+
+// path returns file name of the operation's record
+func (f *FileStore) path(operationID string) string {
+	sum := sha256.Sum256([]byte(operationID))
+	return filepath.Join(f.Dir, hex.EncodeToString(sum[:])+".json")
+}
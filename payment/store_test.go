@@ -0,0 +1,174 @@
+package payment
+
+import (
+	"context"
+	"github.com/hugmouse/goyookassa/consts"
+	"github.com/hugmouse/goyookassa/internal/request"
+	"github.com/shopspring/decimal"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestIdempotenceKeyFromOperation(t *testing.T) {
+	first := IdempotenceKeyFromOperation(http.MethodPost, "payments", "order-37")
+	if first != IdempotenceKeyFromOperation(http.MethodPost, "payments", "order-37") {
+		t.Errorf("IdempotenceKeyFromOperation() is not deterministic")
+	}
+	if first == IdempotenceKeyFromOperation(http.MethodPost, "payments", "order-38") {
+		t.Errorf("IdempotenceKeyFromOperation() returned %q for different operations", first)
+	}
+	if first == IdempotenceKeyFromOperation(http.MethodPost, "refunds", "order-37") {
+		t.Errorf("IdempotenceKeyFromOperation() returned %q for different paths", first)
+	}
+	if len(first) != 36 || first[14] != '5' {
+		t.Errorf("IdempotenceKeyFromOperation() = %q, want UUID v5", first)
+	}
+}
+
+func TestClient_SendIdempotencyStore(t *testing.T) {
+	store := NewMemoryStore()
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		record, err := store.Get(r.Context(), "POST payments order-37")
+		if err != nil || record == nil || record.Response != nil {
+			t.Errorf("record before request = %+v, %v", record, err)
+		} else if got := r.Header.Get(consts.IdempotentHeader); got != record.IdempotenceKey {
+			t.Errorf("Idempotence-Key = %q, want %q", got, record.IdempotenceKey)
+		}
+		_, _ = w.Write([]byte(`{"id":"some-id","status":"pending"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(NewKassa()).SetBaseURL(server.URL).SetIdempotencyStore(store)
+	newPayment := func() *Payment {
+		return NewPayment().SetClient(client).SetOperationID("order-37").SetAmount(decimal.NewFromInt(1), "RUB")
+	}
+
+	first := newPayment()
+	got, err := first.Do()
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	if got.IdempotenceKey != IdempotenceKeyFromOperation(http.MethodPost, "payments", "order-37") {
+		t.Errorf("IdempotenceKey = %q, want key derived from operation", got.IdempotenceKey)
+	}
+
+	replayed, err := newPayment().Do()
+	if err != nil {
+		t.Fatalf("replayed Do() error = %v", err)
+	}
+	if requests != 1 || !reflect.DeepEqual(got, replayed) {
+		t.Errorf("requests = %d, replayed = %+v, want %+v", requests, replayed, got)
+	}
+}
+
+func TestClient_SendIdempotencyStoreRecovery(t *testing.T) {
+	store := NewMemoryStore()
+	record := &IdempotencyRecord{OperationID: "POST payments order-37", IdempotenceKey: "stored-key"}
+	_ = store.Put(context.Background(), record)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get(consts.IdempotentHeader); got != "stored-key" {
+			t.Errorf("Idempotence-Key = %q, want %q", got, "stored-key")
+		}
+		_, _ = w.Write([]byte(`{"id":"some-id"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(NewKassa()).SetBaseURL(server.URL).SetIdempotencyStore(store)
+	ctx := WithOperationID(context.Background(), "order-37")
+	key, err := client.SendWithKey(ctx, http.MethodPost, "payments", "", struct{}{}, nil)
+	if err != nil || key != "stored-key" {
+		t.Fatalf("SendWithKey() = %q, %v, want %q", key, err, "stored-key")
+	}
+
+	record, _ = store.Get(context.Background(), "POST payments order-37")
+	if record == nil || string(record.Response) != `{"id":"some-id"}` {
+		t.Errorf("record = %+v", record)
+	}
+}
+
+func TestClient_SendIdempotencyStoreScope(t *testing.T) {
+	store := NewMemoryStore()
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if r.URL.Path == "/payments" {
+			_, _ = w.Write([]byte(`{"id":"some-id","status":"waiting_for_capture"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"some-id","status":"succeeded"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(NewKassa()).SetBaseURL(server.URL).SetIdempotencyStore(store)
+	created, err := NewPayment().SetClient(client).SetOperationID("order-37").
+		SetAmount(decimal.NewFromInt(1), "RUB").Do()
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+
+	capture := NewCapture(created.ID).SetClient(client).SetOperationID("order-37")
+	captured, err := capture.Do()
+	if err != nil {
+		t.Fatalf("Capture.Do() error = %v", err)
+	}
+	if captured.Status != Succeeded {
+		t.Errorf("Capture.Do() status = %s, want %s", captured.Status, Succeeded)
+	}
+	if captured.IdempotenceKey != IdempotenceKeyFromOperation(http.MethodPost, "payments/some-id/capture", "order-37") {
+		t.Errorf("IdempotenceKey = %q, want key derived from operation", captured.IdempotenceKey)
+	}
+
+	ctx := WithOperationID(context.Background(), "order-37")
+	if _, err := NewCancel(created.ID).SetClient(client).DoContext(ctx); err != nil {
+		t.Fatalf("Cancel.DoContext() error = %v", err)
+	}
+
+	want := []string{"/payments", "/payments/some-id/capture", "/payments/some-id/cancel"}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("paths = %q, want %q", paths, want)
+	}
+}
+
+func TestRequestOperation(t *testing.T) {
+	ctx, key := request.Operation(WithOperationID(context.Background(), "order-99"), http.MethodPost, "payments", "", "")
+	if want := IdempotenceKeyFromOperation(http.MethodPost, "payments", "order-99"); key != want {
+		t.Errorf("key = %q, want %q", key, want)
+	}
+	if OperationIDFrom(ctx) != "order-99" {
+		t.Errorf("OperationIDFrom() = %q, want %q", OperationIDFrom(ctx), "order-99")
+	}
+
+	_, key = request.Operation(context.Background(), http.MethodPost, "payments", "", "")
+	if key == "" || key == IdempotenceKeyFromOperation(http.MethodPost, "payments", "") {
+		t.Errorf("key = %q, want a random key", key)
+	}
+	if _, got := request.Operation(ctx, http.MethodPost, "payments", "", "given"); got != "given" {
+		t.Errorf("key = %q, want %q", got, "given")
+	}
+}
+
+func TestFileStore(t *testing.T) {
+	store, err := NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	if got, err := store.Get(ctx, "order/37"); got != nil || err != nil {
+		t.Errorf("Get() = %+v, %v, want nil", got, err)
+	}
+
+	want := &IdempotencyRecord{OperationID: "order/37", IdempotenceKey: "key", Response: []byte(`{"id":"1"}`)}
+	if err := store.Put(ctx, want); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	got, err := store.Get(ctx, "order/37")
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("Get() = %+v, %v, want %+v", got, err, want)
+	}
+}
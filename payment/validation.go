@@ -0,0 +1,101 @@
+package payment
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrValidation is matched by ValidationErrors, so errors.Is(err, ErrValidation) reports
+// whether request was rejected before sending
+var ErrValidation = errors.New("request validation failed")
+
+// ValidationError is an invalid parameter of a request
+type ValidationError struct {
+	// Parameter is path of the invalid parameter named as in YooKassa's API, like amount.value
+	// or receipt.items[0].vat_code, the same way as YooKassaErrorResponse.Parameter
+	Parameter string
+	// Err describes the problem, like ErrReceiptTotalMismatch
+	Err error
+}
+
+func (e *ValidationError) Error() string {
+	if e.Parameter == "" {
+		return e.Err.Error()
+	}
+	return e.Parameter + ": " + e.Err.Error()
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationErrors is a list of every invalid parameter of a request, returned by Validate methods
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return ErrValidation.Error() + ": " + strings.Join(messages, "; ")
+}
+
+// Is reports whether target is ErrValidation or matches any of the errors
+func (e ValidationErrors) Is(target error) bool {
+	if target == ErrValidation {
+		return true
+	}
+	for _, err := range e {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Add adds an error of the parameter
+func (e *ValidationErrors) Add(parameter, message string) {
+	e.AddError(parameter, errors.New(message))
+}
+
+// AddError adds an error of the parameter, it is kept available to errors.Is
+func (e *ValidationErrors) AddError(parameter string, err error) {
+	*e = append(*e, &ValidationError{Parameter: parameter, Err: err})
+}
+
+// Merge adds errors returned by Validate of a nested object, prefixing their parameters with prefix.
+// Nil err is ignored
+//
+// Example: errs.Merge("receipt", receipt.Validate(&amount))
+func (e *ValidationErrors) Merge(prefix string, err error) {
+	if err == nil {
+		return
+	}
+	var nested ValidationErrors
+	if !errors.As(err, &nested) {
+		e.AddError(prefix, err)
+		return
+	}
+	for _, err := range nested {
+		e.AddError(parameter(prefix, err.Parameter), err.Err)
+	}
+}
+
+// Err returns e as error, or nil if there are no errors
+func (e ValidationErrors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// parameter joins parameter path with name
+func parameter(prefix, name string) string {
+	switch {
+	case prefix == "":
+		return name
+	case name == "" || strings.HasPrefix(name, "["):
+		return prefix + name
+	}
+	return prefix + "." + name
+}
@@ -0,0 +1,125 @@
+package payment
+
+import (
+	"errors"
+	"github.com/shopspring/decimal"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func parameters(err error) []string {
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		return nil
+	}
+	params := make([]string, len(errs))
+	for i, e := range errs {
+		params[i] = e.Parameter
+	}
+	return params
+}
+
+func TestPayment_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		payment *Payment
+		want    []string
+	}{
+		{
+			name: "Valid",
+			payment: NewPayment().SetAmount(decimal.NewFromInt(100), "RUB").
+				SetConfirmation(Confirmation{Type: Redirect, ReturnURL: "https://example.com"}),
+		},
+		{
+			name:    "Zero amount without currency",
+			payment: NewPayment().SetAmount(decimal.Zero, ""),
+			want:    []string{"amount.value", "amount.currency"},
+		},
+		{
+			name:    "Nil method data",
+			payment: NewPayment().SetAmount(decimal.NewFromInt(100), "RUB").SetPaymentMethodData((*SberbankData)(nil)),
+			want:    []string{"payment_method_data"},
+		},
+		{
+			name:    "Raw method data without type",
+			payment: NewPayment().SetAmount(decimal.NewFromInt(100), "RUB").SetPaymentMethodData(RawMethodData{}),
+			want:    []string{"payment_method_data.type"},
+		},
+		{
+			name: "Everything",
+			payment: NewPayment().SetAmount(decimal.NewFromInt(100), "RUB").
+				SetDescription(strings.Repeat("d", PaymentDescriptionMaxLength+1)).
+				SetConfirmation(Confirmation{Type: Redirect}).
+				SetPaymentMethodID("pm-1").
+				SetPaymentMethodData(SBPData{}).
+				SetMetadata(Metadata{strings.Repeat("k", MetadataMaxKeyLength+1): "v"}).
+				SetReceipt(Receipt{Items: []ReceiptItem{{Quantity: decimal.NewFromInt(1), VatCode: 1}}}),
+			want: []string{
+				"description",
+				"confirmation.return_url",
+				"payment_method_data",
+				"metadata." + strings.Repeat("k", MetadataMaxKeyLength+1),
+				"receipt.customer",
+				"receipt.items[0].description",
+				"receipt.items",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.payment.Validate()
+			if tt.want == nil {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrValidation) {
+				t.Errorf("Validate() error = %v, want %v", err, ErrValidation)
+			}
+			if got := parameters(err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() parameters = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPayment_DoValidation(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(`{"id":"some-id","status":"pending"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(NewKassa()).SetBaseURL(server.URL)
+	p := NewPayment().SetClient(client).SetAmount(decimal.Zero, "RUB")
+	if _, err := p.Do(); !errors.Is(err, ErrValidation) {
+		t.Errorf("Do() error = %v, want %v", err, ErrValidation)
+	}
+	if requests != 0 {
+		t.Errorf("requests = %d, want 0", requests)
+	}
+
+	client.SetSkipValidation(true)
+	if _, err := p.Do(); err != nil {
+		t.Errorf("Do() error = %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("requests = %d, want 1", requests)
+	}
+}
+
+func TestCapture_Validate(t *testing.T) {
+	amount := NewMoney(decimal.NewFromInt(-1), "RUB")
+	err := (&Capture{Amount: &amount}).Validate()
+	if got, want := parameters(err), []string{"payment_id", "amount.value"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Validate() parameters = %q, want %q", got, want)
+	}
+	if err := NewCancel("").Validate(); !errors.Is(err, ErrValidation) {
+		t.Errorf("Cancel.Validate() error = %v, want %v", err, ErrValidation)
+	}
+}
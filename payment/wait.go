@@ -0,0 +1,104 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrPaymentExpired is returned by WaitForPayment when payment's expires_at passes before it is completed
+var ErrPaymentExpired = errors.New("payment expired")
+
+// WaitOptions describes how often WaitForPayment polls the payment
+//
+// The interval grows exponentially from InitialInterval to MaxInterval.
+// Zero InitialInterval, MaxInterval and Multiplier are taken from DefaultWaitOptions.
+type WaitOptions struct {
+	// InitialInterval is delay before the second request
+	InitialInterval time.Duration
+	// MaxInterval is maximum delay between requests
+	MaxInterval time.Duration
+	// Multiplier is factor the delay is multiplied by after each request
+	Multiplier float64
+	// Jitter is fraction of the delay that is randomized (0 to 1)
+	Jitter float64
+}
+
+// DefaultWaitOptions returns new WaitOptions with sensible defaults
+func DefaultWaitOptions() *WaitOptions {
+	return &WaitOptions{
+		InitialInterval: time.Second,
+		MaxInterval:     30 * time.Second,
+		Multiplier:      1.5,
+		Jitter:          0.2,
+	}
+}
+
+// WaitForPayment polls the payment until its status is final or waiting_for_capture and returns it
+//
+// It stops when ctx is done or the payment's expires_at passes, returning the last fetched payment
+// together with ctx.Err() or ErrPaymentExpired. API errors are returned as is,
+// set Client.RetryPolicy to repeat temporary failures. If opts is nil, DefaultWaitOptions is used.
+//
+// Example: client.WaitForPayment(ctx, id, payment.DefaultWaitOptions())
+func (c *Client) WaitForPayment(ctx context.Context, id string, opts *WaitOptions) (*PaymentObject, error) {
+	policy := opts.policy()
+
+	var last *PaymentObject
+	for attempt := 1; ; attempt++ {
+		p, err := c.GetPaymentContext(ctx, id)
+		if err != nil {
+			if last != nil && ctx.Err() != nil {
+				return last, ctx.Err()
+			}
+			return nil, err
+		}
+		last = p
+		if p.Status.IsFinal() || p.Status.CanCapture() {
+			return p, nil
+		}
+
+		delay := policy.backoff(attempt, nil)
+		if p.ExpiresAt != nil {
+			left := time.Until(*p.ExpiresAt)
+			if left <= 0 {
+				return p, ErrPaymentExpired
+			}
+			if delay > left {
+				delay = left
+			}
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return p, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// policy returns RetryPolicy that computes delays between requests, filling zero options with defaults
+func (o *WaitOptions) policy() *RetryPolicy {
+	defaults := DefaultWaitOptions()
+	if o == nil {
+		o = defaults
+	}
+	policy := &RetryPolicy{
+		InitialBackoff: o.InitialInterval,
+		MaxBackoff:     o.MaxInterval,
+		Multiplier:     o.Multiplier,
+		Jitter:         o.Jitter,
+	}
+	if policy.InitialBackoff <= 0 {
+		policy.InitialBackoff = defaults.InitialInterval
+	}
+	if policy.MaxBackoff <= 0 {
+		policy.MaxBackoff = defaults.MaxInterval
+	}
+	if policy.Multiplier <= 0 {
+		policy.Multiplier = defaults.Multiplier
+	}
+	return policy
+}
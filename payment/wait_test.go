@@ -0,0 +1,91 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClient_WaitForPayment(t *testing.T) {
+	opts := &WaitOptions{InitialInterval: time.Millisecond, MaxInterval: 5 * time.Millisecond, Multiplier: 2}
+
+	t.Run("Waiting for capture", func(t *testing.T) {
+		requests := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			if requests < 3 {
+				_, _ = w.Write([]byte(`{"id":"1","status":"pending"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"id":"1","status":"waiting_for_capture"}`))
+		}))
+		defer server.Close()
+
+		p, err := NewClient(NewKassa()).SetBaseURL(server.URL).WaitForPayment(context.Background(), "1", opts)
+		if err != nil {
+			t.Fatalf("WaitForPayment() error = %v", err)
+		}
+		if p.Status != WaitingForCapture || requests != 3 {
+			t.Errorf("WaitForPayment() status = %s after %d requests", p.Status, requests)
+		}
+	})
+
+	t.Run("Context deadline", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"id":"1","status":"pending"}`))
+		}))
+		defer server.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		p, err := NewClient(NewKassa()).SetBaseURL(server.URL).WaitForPayment(ctx, "1", opts)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("WaitForPayment() error = %v, want %v", err, context.DeadlineExceeded)
+		}
+		if p == nil || p.Status != Pending {
+			t.Errorf("WaitForPayment() = %+v, want last fetched payment", p)
+		}
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"id":"1","status":"pending","expires_at":"2020-01-01T00:00:00.000Z"}`))
+		}))
+		defer server.Close()
+
+		_, err := NewClient(NewKassa()).SetBaseURL(server.URL).WaitForPayment(context.Background(), "1", opts)
+		if !errors.Is(err, ErrPaymentExpired) {
+			t.Errorf("WaitForPayment() error = %v, want %v", err, ErrPaymentExpired)
+		}
+	})
+
+	t.Run("API error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"type":"error","code":"not_found"}`))
+		}))
+		defer server.Close()
+
+		_, err := NewClient(NewKassa()).SetBaseURL(server.URL).WaitForPayment(context.Background(), "1", opts)
+		if !errors.Is(err, ErrNotFound) {
+			t.Errorf("WaitForPayment() error = %v, want %v", err, ErrNotFound)
+		}
+	})
+}
+
+func TestWaitOptions_policy(t *testing.T) {
+	policy := (&WaitOptions{MaxInterval: time.Minute}).policy()
+	defaults := DefaultWaitOptions()
+	if policy.InitialBackoff != defaults.InitialInterval || policy.Multiplier != defaults.Multiplier {
+		t.Errorf("policy() = %+v, want defaults for zero options", policy)
+	}
+	if policy.MaxBackoff != time.Minute {
+		t.Errorf("policy().MaxBackoff = %v, want %v", policy.MaxBackoff, time.Minute)
+	}
+	if delay := policy.backoff(1, nil); delay <= 0 {
+		t.Errorf("backoff() = %v, want positive delay", delay)
+	}
+}
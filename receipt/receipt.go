@@ -0,0 +1,427 @@
+package receipt
+
+import (
+	"context"
+	"fmt"
+	"github.com/hugmouse/goyookassa/internal/request"
+	"github.com/hugmouse/goyookassa/payment"
+	"github.com/shopspring/decimal"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Type is receipt type
+type Type string
+
+const (
+	// Payment is receipt for incoming funds
+	Payment Type = "payment"
+	// Refund is receipt for refunded funds
+	Refund Type = "refund"
+)
+
+// Status is receipt registration status
+type Status string
+
+const (
+	// Pending means the receipt is being registered
+	Pending Status = "pending"
+	// Succeeded means the receipt was successfully registered
+	Succeeded Status = "succeeded"
+	// Canceled means the receipt was not registered
+	Canceled Status = "canceled"
+)
+
+// SettlementType is type of Settlement
+type SettlementType string
+
+const (
+	// Cashless is payment by non-cash means
+	Cashless SettlementType = "cashless"
+	// Prepayment is offset of the prepayment
+	Prepayment SettlementType = "prepayment"
+	// Postpayment is credit
+	Postpayment SettlementType = "postpayment"
+	// Consideration is counter-provision
+	Consideration SettlementType = "consideration"
+)
+
+// Receipt is used to create a separate receipt, like a second receipt with settlement
+// after the shipment in "payment then shipment" flows
+//
+// Learn more: https://yookassa.ru/en/developers/api#create_receipt
+type Receipt struct {
+	*payment.Kassa `json:"-"`
+
+	// IdempotenceKey works the same way as in payment.Payment
+	IdempotenceKey string `json:"-"`
+
+	// OperationID identifies the operation, see payment.IdempotencyStore
+	OperationID string `json:"-"`
+
+	// Client is used to send the receipt. If nil, a new payment.Client is created for Kassa
+	Client *payment.Client `json:"-"`
+
+	// Type is receipt type: Payment or Refund
+	Type Type `json:"type"`
+
+	// PaymentID is id of the payment the receipt is created for
+	PaymentID string `json:"payment_id,omitempty"`
+
+	// RefundID is id of the refund the receipt is created for
+	RefundID string `json:"refund_id,omitempty"`
+
+	// Customer is user details. You must specify at least the basic contact info: email or phone
+	Customer *payment.Customer `json:"customer"`
+
+	// Items is list of products in the order
+	Items []payment.ReceiptItem `json:"items"`
+
+	// Send is always true: the receipt is sent to the user
+	Send bool `json:"send"`
+
+	// TaxSystemCode is store's tax system (1 to 6)
+	TaxSystemCode int `json:"tax_system_code,omitempty"`
+
+	// Settlements is list of settlements. Settlements total must match items total
+	Settlements []Settlement `json:"settlements"`
+
+	// OnBehalfOf is id of the store on behalf of which the receipt is sent, used in Split payments
+	OnBehalfOf string `json:"on_behalf_of,omitempty"`
+}
+
+// Settlement is information about money distribution in the receipt
+type Settlement struct {
+	// Type is settlement type
+	Type SettlementType `json:"type"`
+	// Amount is settlement amount
+	Amount payment.Amount `json:"amount"`
+}
+
+// ReceiptObject is receipt object returned by YooKassa's endpoint
+type ReceiptObject struct {
+	ID                   string                `json:"id"`
+	Type                 Type                  `json:"type"`
+	PaymentID            string                `json:"payment_id,omitempty"`
+	RefundID             string                `json:"refund_id,omitempty"`
+	Status               Status                `json:"status"`
+	FiscalDocumentNumber string                `json:"fiscal_document_number,omitempty"`
+	FiscalStorageNumber  string                `json:"fiscal_storage_number,omitempty"`
+	FiscalAttribute      string                `json:"fiscal_attribute,omitempty"`
+	RegisteredAt         *time.Time            `json:"registered_at,omitempty"`
+	FiscalProviderID     string                `json:"fiscal_provider_id,omitempty"`
+	Items                []payment.ReceiptItem `json:"items"`
+	Settlements          []Settlement          `json:"settlements,omitempty"`
+	TaxSystemCode        int                   `json:"tax_system_code,omitempty"`
+	OnBehalfOf           string                `json:"on_behalf_of,omitempty"`
+	// IdempotenceKey is idempotence key the request was sent with, it is empty for fetched objects
+	IdempotenceKey string `json:"-"`
+}
+
+// List is a page of receipts
+type List struct {
+	Type       string          `json:"type"`
+	Items      []ReceiptObject `json:"items"`
+	NextCursor string          `json:"next_cursor"`
+}
+
+// ListFilter is used to filter and paginate receipts list
+//
+// Learn more: https://yookassa.ru/en/developers/api#get_receipts_list
+type ListFilter struct {
+	// CreatedAtGte filters receipts created at the specified time or later
+	CreatedAtGte time.Time
+	// CreatedAtGt filters receipts created later than the specified time
+	CreatedAtGt time.Time
+	// CreatedAtLte filters receipts created at the specified time or earlier
+	CreatedAtLte time.Time
+	// CreatedAtLt filters receipts created earlier than the specified time
+	CreatedAtLt time.Time
+	// PaymentID filters receipts of the payment
+	PaymentID string
+	// RefundID filters receipts of the refund
+	RefundID string
+	// Status filters receipts by status
+	Status Status
+	// Limit is size of the page (1 to 100, 10 by default)
+	Limit int
+	// Cursor is NextCursor of the previous page
+	Cursor string
+}
+
+// Client is used to get receipts
+type Client struct {
+	*payment.Client
+}
+
+// NewReceipt creates and initializes a new Receipt of a given type
+func NewReceipt(t Type) *Receipt {
+	return &Receipt{Type: t, Send: true}
+}
+
+// SetKassa sets receipt's YooKassa info (your shop id and shop secret key)
+func (r *Receipt) SetKassa(kassa *payment.Kassa) *Receipt {
+	r.Kassa = kassa
+	return r
+}
+
+// SetClient sets client that is used to send the receipt
+func (r *Receipt) SetClient(client *payment.Client) *Receipt {
+	r.Client = client
+	return r
+}
+
+// SetIdempotenceKey sets receipt's idempotence key
+func (r *Receipt) SetIdempotenceKey(key string) *Receipt {
+	r.IdempotenceKey = key
+	return r
+}
+
+// SetOperationID sets id of the operation, see payment.IdempotencyStore
+func (r *Receipt) SetOperationID(id string) *Receipt {
+	r.OperationID = id
+	return r
+}
+
+// SetPaymentID sets id of the payment the receipt is created for
+func (r *Receipt) SetPaymentID(id string) *Receipt {
+	r.PaymentID = id
+	return r
+}
+
+// SetRefundID sets id of the refund the receipt is created for
+func (r *Receipt) SetRefundID(id string) *Receipt {
+	r.RefundID = id
+	return r
+}
+
+// SetCustomer sets user details
+func (r *Receipt) SetCustomer(customer payment.Customer) *Receipt {
+	r.Customer = &customer
+	return r
+}
+
+// SetItems sets list of products
+func (r *Receipt) SetItems(items []payment.ReceiptItem) *Receipt {
+	r.Items = items
+	return r
+}
+
+// SetTaxSystemCode sets store's tax system
+func (r *Receipt) SetTaxSystemCode(code int) *Receipt {
+	r.TaxSystemCode = code
+	return r
+}
+
+// SetSettlements sets list of settlements
+func (r *Receipt) SetSettlements(settlements []Settlement) *Receipt {
+	r.Settlements = settlements
+	return r
+}
+
+// SetOnBehalfOf sets id of the store on behalf of which the receipt is sent
+func (r *Receipt) SetOnBehalfOf(accountID string) *Receipt {
+	r.OnBehalfOf = accountID
+	return r
+}
+
+// Validate checks receipt items, that Send is set and settlements share the currency and match items total.
+// The error is payment.ValidationErrors with every invalid parameter
+func (r *Receipt) Validate() error {
+	var errs payment.ValidationErrors
+	if r.Type != Payment && r.Type != Refund {
+		errs.Add("type", "receipt type must be payment or refund")
+	}
+	if !r.Send {
+		errs.Add("send", "send must be true, use NewReceipt")
+	}
+	if len(r.Settlements) == 0 {
+		errs.Add("settlements", "receipt must have at least one settlement")
+	}
+
+	var total *payment.Amount
+	if len(r.Settlements) > 0 {
+		total = &payment.Amount{Value: decimal.Zero, Currency: r.Settlements[0].Amount.Currency}
+	}
+	for i, settlement := range r.Settlements {
+		errs.Merge(fmt.Sprintf("settlements[%d].amount", i), settlement.Amount.Validate())
+		if !strings.EqualFold(settlement.Amount.Currency, total.Currency) {
+			errs.Add(fmt.Sprintf("settlements[%d].amount.currency", i),
+				fmt.Sprintf("currency %s doesn't match %s", settlement.Amount.Currency, total.Currency))
+		}
+		total.Value = total.Value.Add(settlement.Amount.Value)
+	}
+	items := payment.Receipt{Customer: r.Customer, Items: r.Items, TaxSystemCode: r.TaxSystemCode}
+	errs.Merge("", items.Validate(total))
+	return errs.Err()
+}
+
+// Do sends an HTTP request to YooKassa receipts endpoint
+func (r *Receipt) Do() (*ReceiptObject, error) {
+	return r.DoContext(context.Background())
+}
+
+// DoContext sends an HTTP request to YooKassa receipts endpoint using the provided context.
+// The receipt is validated first, unless payment.Client.SkipValidation is set
+func (r *Receipt) DoContext(ctx context.Context) (*ReceiptObject, error) {
+	ctx, key := request.Operation(ctx, http.MethodPost, "receipts", r.OperationID, r.IdempotenceKey)
+
+	client := r.Client
+	if client == nil {
+		client = payment.NewClient(r.Kassa)
+	}
+	if !client.SkipValidation {
+		if err := r.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	receipt := new(ReceiptObject)
+	key, err := client.SendWithKey(ctx, http.MethodPost, "receipts", key, r, receipt)
+	if err != nil {
+		return nil, err
+	}
+	receipt.IdempotenceKey = key
+	return receipt, nil
+}
+
+// NewListFilter creates and initializes a new ListFilter
+func NewListFilter() *ListFilter {
+	return &ListFilter{}
+}
+
+// SetCreatedAtGte filters receipts created at t or later
+func (f *ListFilter) SetCreatedAtGte(t time.Time) *ListFilter {
+	f.CreatedAtGte = t
+	return f
+}
+
+// SetCreatedAtGt filters receipts created later than t
+func (f *ListFilter) SetCreatedAtGt(t time.Time) *ListFilter {
+	f.CreatedAtGt = t
+	return f
+}
+
+// SetCreatedAtLte filters receipts created at t or earlier
+func (f *ListFilter) SetCreatedAtLte(t time.Time) *ListFilter {
+	f.CreatedAtLte = t
+	return f
+}
+
+// SetCreatedAtLt filters receipts created earlier than t
+func (f *ListFilter) SetCreatedAtLt(t time.Time) *ListFilter {
+	f.CreatedAtLt = t
+	return f
+}
+
+// SetPaymentID filters receipts of the payment
+func (f *ListFilter) SetPaymentID(id string) *ListFilter {
+	f.PaymentID = id
+	return f
+}
+
+// SetRefundID filters receipts of the refund
+func (f *ListFilter) SetRefundID(id string) *ListFilter {
+	f.RefundID = id
+	return f
+}
+
+// SetStatus filters receipts by status
+func (f *ListFilter) SetStatus(status Status) *ListFilter {
+	f.Status = status
+	return f
+}
+
+// SetLimit sets size of the page (1 to 100)
+func (f *ListFilter) SetLimit(limit int) *ListFilter {
+	f.Limit = limit
+	return f
+}
+
+// SetCursor sets cursor of the page, use List.NextCursor of the previous page
+func (f *ListFilter) SetCursor(cursor string) *ListFilter {
+	f.Cursor = cursor
+	return f
+}
+
+// Validate checks that Limit is from 1 to payment.ListMaxLimit, if it is set.
+// The error is payment.ValidationErrors with limit parameter
+func (f *ListFilter) Validate() error {
+	var errs payment.ValidationErrors
+	if f != nil && (f.Limit < 0 || f.Limit > payment.ListMaxLimit) {
+		errs.Add("limit", fmt.Sprintf("limit must be from 1 to %d", payment.ListMaxLimit))
+	}
+	return errs.Err()
+}
+
+// Query returns filter as URL query parameters
+func (f *ListFilter) Query() url.Values {
+	if f == nil {
+		return url.Values{}
+	}
+	q := request.ListParams{
+		CreatedAtGte: f.CreatedAtGte,
+		CreatedAtGt:  f.CreatedAtGt,
+		CreatedAtLte: f.CreatedAtLte,
+		CreatedAtLt:  f.CreatedAtLt,
+		Status:       string(f.Status),
+		Limit:        f.Limit,
+		Cursor:       f.Cursor,
+	}.Query()
+	if f.PaymentID != "" {
+		q.Set("payment_id", f.PaymentID)
+	}
+	if f.RefundID != "" {
+		q.Set("refund_id", f.RefundID)
+	}
+	return q
+}
+
+// NewClient creates and initializes a new Client on top of payment.Client
+func NewClient(client *payment.Client) *Client {
+	return &Client{Client: client}
+}
+
+// GetReceipt returns receipt by its id
+//
+// API errors are returned as *payment.YooKassaErrorResponse, other unsuccessful responses as *payment.HTTPError.
+func (c *Client) GetReceipt(id string) (*ReceiptObject, error) {
+	return c.GetReceiptContext(context.Background(), id)
+}
+
+// GetReceiptContext returns receipt by its id using the provided context
+func (c *Client) GetReceiptContext(ctx context.Context, id string) (*ReceiptObject, error) {
+	receipt := new(ReceiptObject)
+	if err := c.Send(ctx, http.MethodGet, "receipts/"+url.PathEscape(id), "", nil, receipt); err != nil {
+		return nil, err
+	}
+	return receipt, nil
+}
+
+// ListReceipts returns a page of receipts that match the filter. The filter may be nil
+func (c *Client) ListReceipts(filter *ListFilter) (*List, error) {
+	return c.ListReceiptsContext(context.Background(), filter)
+}
+
+// ListReceiptsContext returns a page of receipts that match the filter using the provided context.
+// The filter is validated first, unless payment.Client.SkipValidation is set
+func (c *Client) ListReceiptsContext(ctx context.Context, filter *ListFilter) (*List, error) {
+	if !c.SkipValidation {
+		if err := filter.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	path := "receipts"
+	if q := filter.Query(); len(q) != 0 {
+		path += "?" + q.Encode()
+	}
+
+	list := new(List)
+	if err := c.Send(ctx, http.MethodGet, path, "", nil, list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
@@ -0,0 +1,115 @@
+package receipt
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/hugmouse/goyookassa/payment"
+	"github.com/shopspring/decimal"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func testReceipt(client *payment.Client) *Receipt {
+	return NewReceipt(Payment).
+		SetClient(client).
+		SetIdempotenceKey("key").
+		SetPaymentID("24b94598-000f-5000-9000-1b68e7b15f3f").
+		SetCustomer(payment.Customer{Email: "user@example.com"}).
+		SetItems([]payment.ReceiptItem{{
+			Description:    "Cup",
+			Quantity:       decimal.NewFromInt(2),
+			Amount:         payment.Amount{Value: decimal.NewFromInt(100), Currency: "RUB"},
+			VatCode:        2,
+			PaymentSubject: payment.PaymentSubjectCommodity,
+			PaymentMode:    payment.PaymentModeFullPayment,
+		}}).
+		SetSettlements([]Settlement{{
+			Type:   Prepayment,
+			Amount: payment.Amount{Value: decimal.NewFromInt(200), Currency: "RUB"},
+		}})
+}
+
+func TestReceipt_Do(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/receipts" {
+			t.Errorf("request = %s %s, want POST /receipts", r.Method, r.URL.Path)
+		}
+		var body map[string]json.RawMessage
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+			return
+		}
+		if string(body["type"]) != `"payment"` || string(body["send"]) != "true" {
+			t.Errorf("type = %s, send = %s", body["type"], body["send"])
+		}
+		_, _ = w.Write([]byte(`{"id":"rt-1","type":"payment","payment_id":"24b94598-000f-5000-9000-1b68e7b15f3f",` +
+			`"status":"pending","items":[],"settlements":[{"type":"prepayment","amount":{"value":"200.00","currency":"RUB"}}]}`))
+	}))
+	defer server.Close()
+
+	got, err := testReceipt(payment.NewClient(payment.NewKassa()).SetBaseURL(server.URL)).Do()
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	if got.ID != "rt-1" || got.Status != Pending || len(got.Settlements) != 1 {
+		t.Errorf("Do() = %+v", got)
+	}
+}
+
+func TestReceipt_Validate(t *testing.T) {
+	r := testReceipt(nil).SetSettlements([]Settlement{{
+		Type:   Prepayment,
+		Amount: payment.Amount{Value: decimal.NewFromInt(100), Currency: "RUB"},
+	}})
+	if err := r.Validate(); !errors.Is(err, payment.ErrReceiptTotalMismatch) {
+		t.Errorf("Validate() error = %v, want %v", err, payment.ErrReceiptTotalMismatch)
+	}
+
+	r = testReceipt(nil).SetSettlements([]Settlement{
+		{Type: Prepayment, Amount: payment.Amount{Value: decimal.NewFromInt(100), Currency: "RUB"}},
+		{Type: Prepayment, Amount: payment.Amount{Value: decimal.NewFromInt(100), Currency: "USD"}},
+	})
+	r.Send = false
+	var errs payment.ValidationErrors
+	if err := r.Validate(); !errors.As(err, &errs) || len(errs) != 2 ||
+		errs[0].Parameter != "send" || errs[1].Parameter != "settlements[1].amount.currency" {
+		t.Errorf("Validate() error = %v, want send and settlements[1].amount.currency", err)
+	}
+}
+
+func TestClient_ListReceipts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		want := "payment_id=24b94598-000f-5000-9000-1b68e7b15f3f&status=succeeded"
+		if r.URL.Path != "/receipts" || r.URL.RawQuery != want {
+			t.Errorf("request = %s?%s, want /receipts?%s", r.URL.Path, r.URL.RawQuery, want)
+		}
+		_, _ = w.Write([]byte(`{"type":"list","items":[{"id":"rt-1","type":"payment","status":"succeeded"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(payment.NewClient(payment.NewKassa()).SetBaseURL(server.URL))
+	got, err := client.ListReceipts(NewListFilter().
+		SetPaymentID("24b94598-000f-5000-9000-1b68e7b15f3f").
+		SetStatus(Succeeded))
+	if err != nil {
+		t.Fatalf("ListReceipts() error = %v", err)
+	}
+	if len(got.Items) != 1 || got.Items[0].Status != Succeeded {
+		t.Errorf("ListReceipts() = %+v", got)
+	}
+}
+
+func TestListFilter_Validate(t *testing.T) {
+	if err := (*ListFilter)(nil).Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	if err := NewListFilter().SetLimit(payment.ListMaxLimit + 1).Validate(); !errors.Is(err, payment.ErrValidation) {
+		t.Errorf("Validate() error = %v, want %v", err, payment.ErrValidation)
+	}
+
+	client := NewClient(payment.NewClient(payment.NewKassa()).SetBaseURL("http://127.0.0.1:0"))
+	if _, err := client.ListReceipts(NewListFilter().SetLimit(-1)); !errors.Is(err, payment.ErrValidation) {
+		t.Errorf("ListReceipts() error = %v, want %v", err, payment.ErrValidation)
+	}
+}
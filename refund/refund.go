@@ -0,0 +1,358 @@
+package refund
+
+import (
+	"context"
+	"fmt"
+	"github.com/hugmouse/goyookassa/internal/request"
+	"github.com/hugmouse/goyookassa/payment"
+	"github.com/shopspring/decimal"
+	"net/http"
+	"net/url"
+	"time"
+	"unicode/utf8"
+)
+
+// DescriptionMaxLength is maximum length of Refund description
+const DescriptionMaxLength = 250
+
+// Status is refund status
+type Status string
+
+const (
+	// Pending means the refund is being processed
+	Pending Status = "pending"
+	// Succeeded means the refund was successfully completed
+	Succeeded Status = "succeeded"
+	// Canceled means the refund was canceled, see RefundObject.CancellationDetails for the reason
+	Canceled Status = "canceled"
+)
+
+// Refund is used to create a refund of a successful payment
+//
+// Learn more: https://yookassa.ru/en/developers/api#create_refund
+type Refund struct {
+	*payment.Kassa `json:"-"`
+
+	// IdempotenceKey works the same way as in payment.Payment
+	IdempotenceKey string `json:"-"`
+
+	// OperationID identifies the operation, see payment.IdempotencyStore
+	OperationID string `json:"-"`
+
+	// Client is used to send the refund. If nil, a new payment.Client is created for Kassa
+	Client *payment.Client `json:"-"`
+
+	// PaymentID is id of the payment to be refunded
+	PaymentID string `json:"payment_id"`
+
+	// Amount to be refunded to the user. Can't exceed the payment amount
+	Amount payment.Amount `json:"amount"`
+
+	// Description is commentary to the refund, reason for the refund (up to DescriptionMaxLength characters)
+	Description string `json:"description,omitempty"`
+
+	// Receipt is data for creating a receipt in accordance with 54-FZ. Items total must match the Amount
+	Receipt *payment.Receipt `json:"receipt,omitempty"`
+
+	// Sources is data on who is returning the money, used in Split payments
+	Sources []Source `json:"sources,omitempty"`
+}
+
+// Source is data on the store that returns the money in Split payments
+type Source struct {
+	// AccountID is id of the store in favor of which you're returning the money
+	AccountID string `json:"account_id"`
+	// Amount to be returned by the store
+	Amount payment.Amount `json:"amount"`
+	// PlatformFeeAmount is commission that you withheld when making the payment and want to return
+	PlatformFeeAmount *payment.Amount `json:"platform_fee_amount,omitempty"`
+}
+
+// RefundObject is refund object returned by YooKassa's endpoint
+type RefundObject struct {
+	ID                  string                       `json:"id"`
+	PaymentID           string                       `json:"payment_id"`
+	Status              Status                       `json:"status"`
+	CancellationDetails *payment.CancellationDetails `json:"cancellation_details,omitempty"`
+	ReceiptRegistration string                       `json:"receipt_registration,omitempty"`
+	CreatedAt           time.Time                    `json:"created_at"`
+	Amount              payment.Amount               `json:"amount"`
+	Description         string                       `json:"description,omitempty"`
+	Sources             []Source                     `json:"sources,omitempty"`
+	// IdempotenceKey is idempotence key the request was sent with, it is empty for fetched objects
+	IdempotenceKey string `json:"-"`
+}
+
+// List is a page of refunds
+type List struct {
+	Type       string         `json:"type"`
+	Items      []RefundObject `json:"items"`
+	NextCursor string         `json:"next_cursor"`
+}
+
+// ListFilter is used to filter and paginate refunds list
+//
+// Learn more: https://yookassa.ru/en/developers/api#get_refunds_list
+type ListFilter struct {
+	// CreatedAtGte filters refunds created at the specified time or later
+	CreatedAtGte time.Time
+	// CreatedAtGt filters refunds created later than the specified time
+	CreatedAtGt time.Time
+	// CreatedAtLte filters refunds created at the specified time or earlier
+	CreatedAtLte time.Time
+	// CreatedAtLt filters refunds created earlier than the specified time
+	CreatedAtLt time.Time
+	// PaymentID filters refunds of the payment
+	PaymentID string
+	// Status filters refunds by status
+	Status Status
+	// Limit is size of the page (1 to 100, 10 by default)
+	Limit int
+	// Cursor is NextCursor of the previous page
+	Cursor string
+}
+
+// Client is used to get refunds
+type Client struct {
+	*payment.Client
+}
+
+// NewRefund creates and initializes a new Refund
+func NewRefund() *Refund {
+	return &Refund{}
+}
+
+// SetKassa sets refund's YooKassa info (your shop id and shop secret key)
+func (r *Refund) SetKassa(kassa *payment.Kassa) *Refund {
+	r.Kassa = kassa
+	return r
+}
+
+// SetClient sets client that is used to send the refund
+func (r *Refund) SetClient(client *payment.Client) *Refund {
+	r.Client = client
+	return r
+}
+
+// SetIdempotenceKey sets refund's idempotence key
+func (r *Refund) SetIdempotenceKey(key string) *Refund {
+	r.IdempotenceKey = key
+	return r
+}
+
+// SetOperationID sets id of the operation, see payment.IdempotencyStore
+func (r *Refund) SetOperationID(id string) *Refund {
+	r.OperationID = id
+	return r
+}
+
+// SetPaymentID sets id of the payment to be refunded
+func (r *Refund) SetPaymentID(id string) *Refund {
+	r.PaymentID = id
+	return r
+}
+
+// SetAmount sets refund's amount of money and money's type
+//
+// Example: refund.NewRefund().SetAmount(decimal.NewFromInt(500), "RUB")
+func (r *Refund) SetAmount(value decimal.Decimal, moneyType string) *Refund {
+	r.Amount = payment.Amount{
+		Value:    value,
+		Currency: moneyType,
+	}
+	return r
+}
+
+// SetDescription sets refund's description (250 character max)
+func (r *Refund) SetDescription(desc string) *Refund {
+	r.Description = desc
+	return r
+}
+
+// SetReceipt sets refund's receipt
+func (r *Refund) SetReceipt(receipt payment.Receipt) *Refund {
+	r.Receipt = &receipt
+	return r
+}
+
+// SetSources sets stores that return the money
+func (r *Refund) SetSources(sources []Source) *Refund {
+	r.Sources = sources
+	return r
+}
+
+// Do sends an HTTP request to YooKassa refund endpoint
+func (r *Refund) Do() (*RefundObject, error) {
+	return r.DoContext(context.Background())
+}
+
+// Validate checks the refund before it is sent: payment id, amount, description and receipt.
+// The error is payment.ValidationErrors with every invalid parameter
+func (r *Refund) Validate() error {
+	var errs payment.ValidationErrors
+	if r.PaymentID == "" {
+		errs.Add("payment_id", "payment id is required")
+	}
+	errs.Merge("amount", r.Amount.Validate())
+	if utf8.RuneCountInString(r.Description) > DescriptionMaxLength {
+		errs.Add("description", fmt.Sprintf("description is longer than %d characters", DescriptionMaxLength))
+	}
+	if r.Receipt != nil {
+		errs.Merge("receipt", r.Receipt.Validate(&r.Amount))
+	}
+	return errs.Err()
+}
+
+// DoContext sends an HTTP request to YooKassa refund endpoint using the provided context.
+// The refund is validated first, unless payment.Client.SkipValidation is set
+func (r *Refund) DoContext(ctx context.Context) (*RefundObject, error) {
+	ctx, key := request.Operation(ctx, http.MethodPost, "refunds", r.OperationID, r.IdempotenceKey)
+
+	client := r.Client
+	if client == nil {
+		client = payment.NewClient(r.Kassa)
+	}
+	if !client.SkipValidation {
+		if err := r.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	refund := new(RefundObject)
+	key, err := client.SendWithKey(ctx, http.MethodPost, "refunds", key, r, refund)
+	if err != nil {
+		return nil, err
+	}
+	refund.IdempotenceKey = key
+	return refund, nil
+}
+
+// NewListFilter creates and initializes a new ListFilter
+func NewListFilter() *ListFilter {
+	return &ListFilter{}
+}
+
+// SetCreatedAtGte filters refunds created at t or later
+func (f *ListFilter) SetCreatedAtGte(t time.Time) *ListFilter {
+	f.CreatedAtGte = t
+	return f
+}
+
+// SetCreatedAtGt filters refunds created later than t
+func (f *ListFilter) SetCreatedAtGt(t time.Time) *ListFilter {
+	f.CreatedAtGt = t
+	return f
+}
+
+// SetCreatedAtLte filters refunds created at t or earlier
+func (f *ListFilter) SetCreatedAtLte(t time.Time) *ListFilter {
+	f.CreatedAtLte = t
+	return f
+}
+
+// SetCreatedAtLt filters refunds created earlier than t
+func (f *ListFilter) SetCreatedAtLt(t time.Time) *ListFilter {
+	f.CreatedAtLt = t
+	return f
+}
+
+// SetPaymentID filters refunds of the payment
+func (f *ListFilter) SetPaymentID(id string) *ListFilter {
+	f.PaymentID = id
+	return f
+}
+
+// SetStatus filters refunds by status
+func (f *ListFilter) SetStatus(status Status) *ListFilter {
+	f.Status = status
+	return f
+}
+
+// SetLimit sets size of the page (1 to 100)
+func (f *ListFilter) SetLimit(limit int) *ListFilter {
+	f.Limit = limit
+	return f
+}
+
+// SetCursor sets cursor of the page, use List.NextCursor of the previous page
+func (f *ListFilter) SetCursor(cursor string) *ListFilter {
+	f.Cursor = cursor
+	return f
+}
+
+// Validate checks that Limit is from 1 to payment.ListMaxLimit, if it is set.
+// The error is payment.ValidationErrors with limit parameter
+func (f *ListFilter) Validate() error {
+	var errs payment.ValidationErrors
+	if f != nil && (f.Limit < 0 || f.Limit > payment.ListMaxLimit) {
+		errs.Add("limit", fmt.Sprintf("limit must be from 1 to %d", payment.ListMaxLimit))
+	}
+	return errs.Err()
+}
+
+// Query returns filter as URL query parameters
+func (f *ListFilter) Query() url.Values {
+	if f == nil {
+		return url.Values{}
+	}
+	q := request.ListParams{
+		CreatedAtGte: f.CreatedAtGte,
+		CreatedAtGt:  f.CreatedAtGt,
+		CreatedAtLte: f.CreatedAtLte,
+		CreatedAtLt:  f.CreatedAtLt,
+		Status:       string(f.Status),
+		Limit:        f.Limit,
+		Cursor:       f.Cursor,
+	}.Query()
+	if f.PaymentID != "" {
+		q.Set("payment_id", f.PaymentID)
+	}
+	return q
+}
+
+// NewClient creates and initializes a new Client on top of payment.Client
+func NewClient(client *payment.Client) *Client {
+	return &Client{Client: client}
+}
+
+// GetRefund returns refund by its id
+//
+// API errors are returned as *payment.YooKassaErrorResponse, other unsuccessful responses as *payment.HTTPError.
+func (c *Client) GetRefund(id string) (*RefundObject, error) {
+	return c.GetRefundContext(context.Background(), id)
+}
+
+// GetRefundContext returns refund by its id using the provided context
+func (c *Client) GetRefundContext(ctx context.Context, id string) (*RefundObject, error) {
+	refund := new(RefundObject)
+	if err := c.Send(ctx, http.MethodGet, "refunds/"+url.PathEscape(id), "", nil, refund); err != nil {
+		return nil, err
+	}
+	return refund, nil
+}
+
+// ListRefunds returns a page of refunds that match the filter. The filter may be nil
+func (c *Client) ListRefunds(filter *ListFilter) (*List, error) {
+	return c.ListRefundsContext(context.Background(), filter)
+}
+
+// ListRefundsContext returns a page of refunds that match the filter using the provided context.
+// The filter is validated first, unless payment.Client.SkipValidation is set
+func (c *Client) ListRefundsContext(ctx context.Context, filter *ListFilter) (*List, error) {
+	if !c.SkipValidation {
+		if err := filter.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	path := "refunds"
+	if q := filter.Query(); len(q) != 0 {
+		path += "?" + q.Encode()
+	}
+
+	list := new(List)
+	if err := c.Send(ctx, http.MethodGet, path, "", nil, list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
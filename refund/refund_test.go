@@ -0,0 +1,132 @@
+package refund
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/hugmouse/goyookassa/payment"
+	"github.com/shopspring/decimal"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const refundJSON = `{"id":"216749f7-0016-50be-b000-078d43a63ae4","status":"succeeded",` +
+	`"amount":{"value":"1.00","currency":"RUB"},"created_at":"2017-10-04T19:27:51.407Z",` +
+	`"payment_id":"216749da-000f-50be-b000-096747fad91e"}`
+
+func TestRefund_Do(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/refunds" {
+			t.Errorf("request = %s %s, want POST /refunds", r.Method, r.URL.Path)
+		}
+		var body map[string]json.RawMessage
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+			return
+		}
+		if got := string(body["payment_id"]); got != `"216749da-000f-50be-b000-096747fad91e"` {
+			t.Errorf("payment_id = %s", got)
+		}
+		_, _ = w.Write([]byte(refundJSON))
+	}))
+	defer server.Close()
+
+	got, err := NewRefund().
+		SetClient(payment.NewClient(payment.NewKassa()).SetBaseURL(server.URL)).
+		SetIdempotenceKey("key").
+		SetPaymentID("216749da-000f-50be-b000-096747fad91e").
+		SetAmount(decimal.NewFromInt(1), "RUB").
+		Do()
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	if got.Status != Succeeded || !got.Amount.Value.Equal(decimal.NewFromInt(1)) {
+		t.Errorf("Do() = %+v", got)
+	}
+}
+
+func TestClient_ListRefunds(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		want := "created_at.gte=2021-08-01T00%3A00%3A00.000Z&cursor=abc&limit=50&status=succeeded"
+		if r.URL.RawQuery != want {
+			t.Errorf("query = %q, want %q", r.URL.RawQuery, want)
+		}
+		_, _ = w.Write([]byte(`{"type":"list","items":[` + refundJSON + `],"next_cursor":"def"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(payment.NewClient(payment.NewKassa()).SetBaseURL(server.URL))
+	got, err := client.ListRefunds(NewListFilter().
+		SetCreatedAtGte(time.Date(2021, 8, 1, 0, 0, 0, 0, time.UTC)).
+		SetStatus(Succeeded).
+		SetLimit(50).
+		SetCursor("abc"))
+	if err != nil {
+		t.Fatalf("ListRefunds() error = %v", err)
+	}
+	if len(got.Items) != 1 || got.NextCursor != "def" {
+		t.Errorf("ListRefunds() = %+v", got)
+	}
+}
+
+func TestRefund_Validate(t *testing.T) {
+	err := NewRefund().SetDescription(strings.Repeat("d", DescriptionMaxLength+1)).Validate()
+	var errs payment.ValidationErrors
+	if !errors.As(err, &errs) || len(errs) != 4 {
+		t.Fatalf("Validate() error = %v, want 4 errors", err)
+	}
+	want := []string{"payment_id", "amount.value", "amount.currency", "description"}
+	for i, e := range errs {
+		if e.Parameter != want[i] {
+			t.Errorf("Validate()[%d] parameter = %q, want %q", i, e.Parameter, want[i])
+		}
+	}
+}
+
+func TestRefund_DoSharedOperationID(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if r.URL.Path == "/payments" {
+			_, _ = w.Write([]byte(`{"id":"216749da-000f-50be-b000-096747fad91e","status":"succeeded"}`))
+			return
+		}
+		_, _ = w.Write([]byte(refundJSON))
+	}))
+	defer server.Close()
+
+	client := payment.NewClient(payment.NewKassa()).SetBaseURL(server.URL).SetIdempotencyStore(payment.NewMemoryStore())
+	p, err := payment.NewPayment().SetClient(client).SetOperationID("order-37").
+		SetAmount(decimal.NewFromInt(1), "RUB").Do()
+	if err != nil {
+		t.Fatalf("payment Do() error = %v", err)
+	}
+
+	got, err := NewRefund().SetClient(client).SetOperationID("order-37").
+		SetPaymentID(p.ID).SetAmount(decimal.NewFromInt(1), "RUB").Do()
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	if got.ID != "216749f7-0016-50be-b000-078d43a63ae4" || got.PaymentID != p.ID {
+		t.Errorf("Do() = %+v, want refund", got)
+	}
+	if len(paths) != 2 || paths[1] != "/refunds" {
+		t.Errorf("paths = %q, want refund to be sent", paths)
+	}
+}
+
+func TestListFilter_Validate(t *testing.T) {
+	if err := (*ListFilter)(nil).Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	if err := NewListFilter().SetLimit(payment.ListMaxLimit + 1).Validate(); !errors.Is(err, payment.ErrValidation) {
+		t.Errorf("Validate() error = %v, want %v", err, payment.ErrValidation)
+	}
+
+	client := NewClient(payment.NewClient(payment.NewKassa()).SetBaseURL("http://127.0.0.1:0"))
+	if _, err := client.ListRefunds(NewListFilter().SetLimit(-1)); !errors.Is(err, payment.ErrValidation) {
+		t.Errorf("ListRefunds() error = %v, want %v", err, payment.ErrValidation)
+	}
+}
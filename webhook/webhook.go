@@ -0,0 +1,88 @@
+package webhook
+
+import (
+	"context"
+	"github.com/hugmouse/goyookassa/internal/request"
+	"github.com/hugmouse/goyookassa/notification"
+	"github.com/hugmouse/goyookassa/payment"
+	"net/http"
+	"net/url"
+)
+
+// Webhook is a subscription to notifications about an event
+//
+// Webhooks API is available only with OAuth token issued to partner applications,
+// so the payment.Client must be created with payment.OAuthToken credentials.
+//
+// Learn more: https://yookassa.ru/en/developers/api#webhook
+type Webhook struct {
+	// ID is webhook's id in YooKassa
+	ID string `json:"id,omitempty"`
+	// Event is the event you want to receive notifications about
+	Event notification.Event `json:"event"`
+	// URL is the address notifications are sent to
+	URL string `json:"url"`
+	// IdempotenceKey is idempotence key the request was sent with, it is empty for fetched objects
+	IdempotenceKey string `json:"-"`
+}
+
+// List is list of webhooks
+type List struct {
+	Type  string    `json:"type"`
+	Items []Webhook `json:"items"`
+}
+
+// Client is used to manage webhooks
+type Client struct {
+	*payment.Client
+}
+
+// NewClient creates and initializes a new Client on top of payment.Client
+//
+// Example: webhook.NewClient(payment.NewClient(nil).SetAuth(payment.OAuthToken(token)))
+func NewClient(client *payment.Client) *Client {
+	return &Client{Client: client}
+}
+
+// CreateWebhook subscribes webhookURL to notifications about the event.
+// If idempotenceKey is empty, a new one is generated and returned as Webhook.IdempotenceKey
+func (c *Client) CreateWebhook(event notification.Event, webhookURL, idempotenceKey string) (*Webhook, error) {
+	return c.CreateWebhookContext(context.Background(), event, webhookURL, idempotenceKey)
+}
+
+// CreateWebhookContext subscribes webhookURL to notifications about the event using the provided context
+func (c *Client) CreateWebhookContext(ctx context.Context, event notification.Event,
+	webhookURL, idempotenceKey string) (*Webhook, error) {
+	ctx, key := request.Operation(ctx, http.MethodPost, "webhooks", "", idempotenceKey)
+	webhook := new(Webhook)
+	key, err := c.SendWithKey(ctx, http.MethodPost, "webhooks", key, &Webhook{Event: event, URL: webhookURL}, webhook)
+	if err != nil {
+		return nil, err
+	}
+	webhook.IdempotenceKey = key
+	return webhook, nil
+}
+
+// ListWebhooks returns list of webhooks of the application
+func (c *Client) ListWebhooks() (*List, error) {
+	return c.ListWebhooksContext(context.Background())
+}
+
+// ListWebhooksContext returns list of webhooks of the application using the provided context
+func (c *Client) ListWebhooksContext(ctx context.Context) (*List, error) {
+	list := new(List)
+	if err := c.Send(ctx, http.MethodGet, "webhooks", "", nil, list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// DeleteWebhook unsubscribes webhook by its id
+func (c *Client) DeleteWebhook(id string) error {
+	return c.DeleteWebhookContext(context.Background(), id)
+}
+
+// DeleteWebhookContext unsubscribes webhook by its id using the provided context
+func (c *Client) DeleteWebhookContext(ctx context.Context, id string) error {
+	return c.Send(ctx, http.MethodDelete, "webhooks/"+url.PathEscape(id), "", nil, nil)
+}
@@ -0,0 +1,59 @@
+package webhook
+
+import (
+	"encoding/json"
+	"github.com/hugmouse/goyookassa/notification"
+	"github.com/hugmouse/goyookassa/payment"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer token" {
+			t.Errorf("Authorization = %q, want %q", got, "Bearer token")
+		}
+		switch r.Method + " " + r.URL.Path {
+		case "POST /webhooks":
+			var body Webhook
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Error(err)
+				return
+			}
+			body.ID = "wh-1"
+			_ = json.NewEncoder(w).Encode(body)
+		case "GET /webhooks":
+			_, _ = w.Write([]byte(`{"type":"list","items":[{"id":"wh-1","event":"payment.succeeded",` +
+				`"url":"https://www.merchant-website.com/notification_url"}]}`))
+		case "DELETE /webhooks/wh-1":
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(payment.NewClient(nil).SetAuth(payment.OAuthToken("token")).SetBaseURL(server.URL))
+
+	created, err := client.CreateWebhook(notification.PaymentSucceeded,
+		"https://www.merchant-website.com/notification_url", "key")
+	if err != nil {
+		t.Fatalf("CreateWebhook() error = %v", err)
+	}
+	if created.ID != "wh-1" || created.Event != notification.PaymentSucceeded {
+		t.Errorf("CreateWebhook() = %+v", created)
+	}
+
+	list, err := client.ListWebhooks()
+	if err != nil {
+		t.Fatalf("ListWebhooks() error = %v", err)
+	}
+	if len(list.Items) != 1 || list.Items[0].ID != "wh-1" {
+		t.Errorf("ListWebhooks() = %+v", list)
+	}
+
+	if err := client.DeleteWebhook("wh-1"); err != nil {
+		t.Errorf("DeleteWebhook() error = %v", err)
+	}
+}